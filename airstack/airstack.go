@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"sync"
 	"time"
 )
 
@@ -16,6 +19,7 @@ const (
 	apiTimeout                = 60 * time.Second
 	successStatusCode         = 200
 	unprocessableEntityStatus = 422
+	zeroAddress               = "0x0000000000000000000000000000000000000000"
 )
 
 // SendRequest handles HTTP requests to the Airstack API.
@@ -57,13 +61,72 @@ func SendRequest(ctx context.Context, method, url string, headers map[string]str
 type AirstackClient struct {
 	APIKey string
 	URL    string
+	// TopHoldersTTL is how long GetTopHolders serves a cached holder set
+	// before refreshing it in the background.
+	TopHoldersTTL time.Duration
+	// AdaptivePaging enables tuning page sizes from the observed latency and
+	// size of responses.
+	AdaptivePaging bool
+	// AdaptiveLatencyTarget is the page latency adaptive paging aims for.
+	AdaptiveLatencyTarget time.Duration
+	// AuditLog, when set, receives a JSON line describing every request.
+	AuditLog io.Writer
+	// Logger receives structured warnings, such as deprecation notices. The
+	// default slog logger is used when nil.
+	Logger *slog.Logger
+	// Limiter, when set, throttles every request sent by the client.
+	Limiter *RateLimiter
+	// Backfill configures the requests made with a WithBackfill context.
+	Backfill *BackfillConfig
+	// PageRetries is how many times a failed page is retried from its
+	// cursor before a pagination fails, waiting PageRetryBackoff before the
+	// first retry and doubling it on each one.
+	PageRetries      int
+	PageRetryBackoff time.Duration
+	// Capabilities lists the roots supported per blockchain, checked by the
+	// helpers before sending requests.
+	Capabilities *Capabilities
+	// DefaultLabel attributes requests whose context carries no label.
+	DefaultLabel string
+	// ReproDir, when set, receives a Reproduction file for every failed
+	// call, with the variables listed in DefaultRedactVariables and
+	// RedactVariables redacted. ReproUnredacted drops the default list.
+	ReproDir        string
+	RedactVariables []string
+	ReproUnredacted bool
+	// OnValidationIssue receives the records failing a registered Validator.
+	OnValidationIssue func(ValidationIssue)
+	// TokenFilter, when set, drops the balances and transfers of tokens it
+	// rejects from every helper result.
+	TokenFilter *TokenFilter
+	// DeduplicateBalances merges the rows of balance helpers that describe
+	// the same asset, see DedupeBalances.
+	DeduplicateBalances bool
+
+	mu        sync.Mutex
+	auditMu   sync.Mutex
+	holders   *ttlCache[[]TokenHolder]
+	pageSizes *pageSizer
+	metrics   *metrics
+	firstSeen *ttlCache[WalletAge]
+	limits    map[string]Limits
+	groups    map[string]*requestGroup
+	backfill  *RateLimiter
+	jobs      map[string]*Job
+
+	validators []Validator
+
+	deprecations *deprecations
 }
 
 // NewAirstackClient initializes a new Airstack client.
 func NewAirstackClient(apiKey string) *AirstackClient {
 	return &AirstackClient{
-		APIKey: apiKey,
-		URL:    apiEndpointProd,
+		APIKey:        apiKey,
+		URL:           apiEndpointProd,
+		TopHoldersTTL: defaultTopHoldersTTL,
+		Capabilities:  DefaultCapabilities(),
+		PageRetries:   defaultPageRetries,
 	}
 }
 
@@ -79,6 +142,11 @@ type TokenBalance struct {
 
 // GetTokenBalances queries for token balances with given parameters.
 func (client *AirstackClient) GetTokenBalances(ctx context.Context, variables map[string]interface{}) ([]TokenBalance, error) {
+	if blockchain, ok := variables["blockchain"].(string); ok {
+		if err := client.checkChain(blockchain, QueryTokenBalances); err != nil {
+			return nil, err
+		}
+	}
 	query := `
 	query GetTokensHeldByWalletAddress($identity: Identity, $tokenType: [TokenType!], $blockchain: TokenBlockchain!, $limit: Int) {
 		TokenBalances(
@@ -112,14 +180,23 @@ func (client *AirstackClient) GetTokenBalances(ctx context.Context, variables ma
 		return nil, err
 	}
 
-	return respData.TokenBalances.TokenBalance, nil
+	balances := client.filterBalances(validate(client, "GetTokenBalances", respData.TokenBalances.TokenBalance))
+	owner, _ := variables["identity"].(string)
+	return client.dedupeOwner(owner, balances), nil
 }
 
 // QueryResponse holds the GraphQL query response structure.
 type QueryResponse struct {
-	Data         json.RawMessage
-	StatusCode   int
-	Error        string
+	Data       json.RawMessage
+	StatusCode int
+	Error      string
+	// Raw is the full response body, Errors and RawExtensions the raw
+	// "errors" and "extensions" fields of the GraphQL envelope, when present.
+	Raw           json.RawMessage
+	Errors        json.RawMessage
+	RawExtensions json.RawMessage
+	// Extensions is the typed form of RawExtensions, nil when absent.
+	Extensions   *Extensions
 	HasNextPage  bool
 	HasPrevPage  bool
 	NextPageFunc func() (*QueryResponse, error)
@@ -128,12 +205,63 @@ type QueryResponse struct {
 
 // ExecuteQuery sends a GraphQL query to the Airstack API and returns the parsed response.
 func (client *AirstackClient) ExecuteQuery(ctx context.Context, query string, variables map[string]interface{}) (*QueryResponse, error) {
+	done := trackGroup(ctx)
+	start := time.Now()
+	resp, sent, err := client.executeQuery(ctx, query, variables)
+	done()
+
+	info := RequestInfo{
+		Time:      start,
+		Operation: OperationName(query),
+		Label:     client.label(ctx),
+		Duration:  time.Since(start),
+		Sent:      sent,
+		Canceled:  ctx.Err() != nil && errors.Is(err, ctx.Err()),
+		Err:       err,
+	}
+	if resp != nil {
+		info.StatusCode = resp.StatusCode
+		info.Bytes = len(resp.Data)
+		if resp.Extensions != nil {
+			if resp.Extensions.Cost != nil {
+				info.Credits = resp.Extensions.Cost.Credits
+			}
+			for _, hint := range resp.Extensions.Deprecations {
+				client.noteDeprecation(info.Operation, hint.Field, hint.Reason, DeprecationSourceResponse)
+			}
+		}
+		if info.Err == nil && resp.Error != "" {
+			info.Err = errors.New(resp.Error)
+		}
+	}
+	client.record(ctx, info)
+	if info.Err != nil && !info.Canceled && client.ReproDir != "" {
+		client.captureFailure(query, variables, resp, info.Err)
+	}
+	return resp, err
+}
+
+// executeQuery performs the request behind ExecuteQuery. sent reports
+// whether the request was handed to the HTTP transport, as opposed to failing
+// while waiting for the rate limiters or encoding the body.
+func (client *AirstackClient) executeQuery(ctx context.Context, query string, variables map[string]interface{}) (resp *QueryResponse, sent bool, err error) {
+	if isBackfill(ctx) {
+		if err := client.waitBackfill(ctx); err != nil {
+			return nil, false, err
+		}
+	}
+	if client.Limiter != nil {
+		if err := client.Limiter.Wait(ctx); err != nil {
+			return nil, false, err
+		}
+	}
+
 	body, err := json.Marshal(map[string]interface{}{
 		"query":     query,
 		"variables": variables,
 	})
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	headers := map[string]string{
@@ -142,34 +270,66 @@ func (client *AirstackClient) ExecuteQuery(ctx context.Context, query string, va
 	}
 
 	response, statusCode, err := SendRequest(ctx, "POST", client.URL, headers, body)
+	sent = true
+	if err != nil && ctx.Err() != nil {
+		// The caller canceled the request, which is not an API failure.
+		return nil, sent, ctx.Err()
+	}
 	if err != nil || statusCode != successStatusCode {
 		return &QueryResponse{
 			StatusCode: statusCode,
 			Error:      fmt.Sprintf("HTTP error: %s, Status Code: %d", err, statusCode),
-		}, nil
+			Raw:        response,
+		}, sent, nil
 	}
 
 	var respData map[string]json.RawMessage
 	if err := json.Unmarshal(response, &respData); err != nil {
-		return nil, err
+		return nil, sent, err
 	}
 
 	// Check for "errors" field in response JSON
 	if errorField, ok := respData["errors"]; ok {
 		return &QueryResponse{
-			Data:       nil,
-			StatusCode: statusCode,
-			Error:      string(errorField),
-		}, nil
+			Data:          nil,
+			StatusCode:    statusCode,
+			Error:         string(errorField),
+			Raw:           response,
+			Errors:        errorField,
+			RawExtensions: respData["extensions"],
+			Extensions:    parseExtensions(respData["extensions"]),
+		}, sent, nil
 	}
 
 	// Here you would handle pagination based on the response structure,
 	// setting HasNextPage, HasPrevPage, NextPageFunc, and PrevPageFunc as needed.
 
 	return &QueryResponse{
-		Data:       respData["data"],
-		StatusCode: statusCode,
-	}, nil
+		Data:          respData["data"],
+		StatusCode:    statusCode,
+		Raw:           response,
+		RawExtensions: respData["extensions"],
+		Extensions:    parseExtensions(respData["extensions"]),
+	}, sent, nil
+}
+
+// ExecuteQueryInto executes a GraphQL query, decodes its data into out and
+// returns the full response envelope, so custom queries can read typed results
+// and the raw errors and extensions of the same request.
+func (client *AirstackClient) ExecuteQueryInto(ctx context.Context, query string, variables map[string]interface{}, out interface{}) (*QueryResponse, error) {
+	resp, err := client.ExecuteQuery(ctx, query, variables)
+	if err != nil {
+		return nil, err
+	}
+	if err := responseError(resp); err != nil {
+		return resp, err
+	}
+	if len(resp.Data) > 0 && out != nil {
+		if err := json.Unmarshal(resp.Data, out); err != nil {
+			return resp, err
+		}
+	}
+	return resp, nil
 }
 
 // ExecutePaginatedQuery would be implemented here, focusing on handling pagination logic,
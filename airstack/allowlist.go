@@ -0,0 +1,86 @@
+package airstack
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// AllowlistActionType is the kind of change reconciliation proposes for an
+// allowlist entry.
+type AllowlistActionType string
+
+// Allowlist action types.
+const (
+	AllowlistAdd    AllowlistActionType = "add"
+	AllowlistRemove AllowlistActionType = "remove"
+	AllowlistUpdate AllowlistActionType = "update"
+)
+
+// AllowlistAction is a single change needed to bring an allowlist in line with
+// the latest holder set. OldWeight is nil for additions and NewWeight is nil
+// for removals.
+type AllowlistAction struct {
+	Type      AllowlistActionType
+	Address   string
+	OldWeight *big.Int
+	NewWeight *big.Int
+}
+
+// AllowlistReport summarizes a reconciliation.
+type AllowlistReport struct {
+	Actions   []AllowlistAction
+	Added     int
+	Removed   int
+	Updated   int
+	Unchanged int
+}
+
+func (r AllowlistReport) String() string {
+	return fmt.Sprintf("%d added, %d removed, %d updated, %d unchanged",
+		r.Added, r.Removed, r.Updated, r.Unchanged)
+}
+
+// ReconcileAllowlist compares an existing allowlist, mapping addresses to
+// weights, with the latest holder set and returns the actions turning the
+// former into the latter. Addresses are compared case-insensitively and
+// actions are sorted by address.
+func ReconcileAllowlist(allowlist map[string]*big.Int, holders []TokenHolder) AllowlistReport {
+	current := make(map[string]*big.Int, len(allowlist))
+	for addr, weight := range allowlist {
+		if weight == nil {
+			weight = new(big.Int)
+		}
+		current[strings.ToLower(addr)] = weight
+	}
+	latest := make(map[string]*big.Int, len(holders))
+	for _, h := range holders {
+		latest[strings.ToLower(h.Address)] = h.Amount
+	}
+
+	var report AllowlistReport
+	for addr, weight := range latest {
+		old, ok := current[addr]
+		switch {
+		case !ok:
+			report.Actions = append(report.Actions, AllowlistAction{Type: AllowlistAdd, Address: addr, NewWeight: weight})
+			report.Added++
+		case old.Cmp(weight) != 0:
+			report.Actions = append(report.Actions, AllowlistAction{Type: AllowlistUpdate, Address: addr, OldWeight: old, NewWeight: weight})
+			report.Updated++
+		default:
+			report.Unchanged++
+		}
+	}
+	for addr, weight := range current {
+		if _, ok := latest[addr]; !ok {
+			report.Actions = append(report.Actions, AllowlistAction{Type: AllowlistRemove, Address: addr, OldWeight: weight})
+			report.Removed++
+		}
+	}
+	sort.Slice(report.Actions, func(i, j int) bool {
+		return report.Actions[i].Address < report.Actions[j].Address
+	})
+	return report
+}
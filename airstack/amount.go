@@ -0,0 +1,168 @@
+package airstack
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// ErrUnknownNativeCurrency is returned for blockchains missing from
+// NativeCurrencies.
+var ErrUnknownNativeCurrency = errors.New("airstack: unknown native currency")
+
+// groupedRe matches an integer grouped in thousands by commas, e.g. "1,234".
+var groupedRe = regexp.MustCompile(`^[0-9]{1,3}(?:,[0-9]{3})+$`)
+
+// NativeCurrency is the native token of a blockchain.
+type NativeCurrency struct {
+	Symbol   string
+	Decimals int
+}
+
+// NativeCurrencies lists the native token of the blockchains supported by
+// Airstack.
+var NativeCurrencies = map[string]NativeCurrency{
+	"ethereum": {Symbol: "ETH", Decimals: 18},
+	"base":     {Symbol: "ETH", Decimals: 18},
+	"zora":     {Symbol: "ETH", Decimals: 18},
+	"polygon":  {Symbol: "POL", Decimals: 18},
+}
+
+// LookupNativeCurrency returns the native token of blockchain, matched
+// case-insensitively, or an error wrapping ErrUnknownNativeCurrency.
+func LookupNativeCurrency(blockchain string) (NativeCurrency, error) {
+	currency, ok := NativeCurrencies[strings.ToLower(blockchain)]
+	if !ok {
+		return NativeCurrency{}, fmt.Errorf("%w for blockchain %q", ErrUnknownNativeCurrency, blockchain)
+	}
+	return currency, nil
+}
+
+// WholeUnits is the AmountFormat precision showing no fractional digits.
+const WholeUnits = -1
+
+// AmountFormat configures FormatAmount.
+type AmountFormat struct {
+	// Decimals is the number of decimals of the token.
+	Decimals int
+	// Precision is the maximum number of fractional digits shown; extra
+	// digits are truncated. Zero shows every digit and WholeUnits none.
+	Precision int
+	// Symbol, when set, is appended after a space.
+	Symbol string
+}
+
+// FormatAmount renders a raw base-unit amount with the decimal point placed
+// according to f.Decimals, thousands separators and trailing fractional zeros
+// removed, e.g. "1,234.56 USDC".
+func FormatAmount(amount *big.Int, f AmountFormat) string {
+	if amount == nil {
+		amount = new(big.Int)
+	}
+	abs := new(big.Int).Abs(amount)
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(max(f.Decimals, 0))), nil)
+	whole, frac := new(big.Int).QuoRem(abs, unit, new(big.Int))
+
+	var b strings.Builder
+	if amount.Sign() < 0 {
+		b.WriteByte('-')
+	}
+	b.WriteString(groupThousands(whole.String()))
+	if f.Decimals > 0 {
+		digits := fmt.Sprintf("%0*s", f.Decimals, frac.String())
+		switch {
+		case f.Precision < 0:
+			digits = ""
+		case f.Precision > 0 && f.Precision < len(digits):
+			digits = digits[:f.Precision]
+		}
+		if digits = strings.TrimRight(digits, "0"); digits != "" {
+			b.WriteByte('.')
+			b.WriteString(digits)
+		}
+	}
+	if f.Symbol != "" {
+		b.WriteByte(' ')
+		b.WriteString(f.Symbol)
+	}
+	return b.String()
+}
+
+// FormatNativeAmount renders a raw amount of the native token of blockchain
+// with up to precision fractional digits, as in AmountFormat, e.g. "0.5 ETH".
+func FormatNativeAmount(blockchain string, amount *big.Int, precision int) (string, error) {
+	currency, err := LookupNativeCurrency(blockchain)
+	if err != nil {
+		return "", err
+	}
+	return FormatAmount(amount, AmountFormat{Decimals: currency.Decimals, Precision: precision, Symbol: currency.Symbol}), nil
+}
+
+// groupThousands inserts commas every three digits of an unsigned integer.
+func groupThousands(digits string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+	var b strings.Builder
+	head := len(digits) % 3
+	if head > 0 {
+		b.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
+
+// ParseAmount converts human input such as "1,234.56" or "1234.56 USDC" into
+// base units of a token with the given decimals. Input with more fractional
+// digits than decimals is rejected rather than rounded. Commas are only
+// accepted as thousands separators of the integer part and whitespace only
+// before a trailing symbol, so ambiguous input such as "1,5" or "1 000" is an
+// error.
+func ParseAmount(input string, decimals int) (*big.Int, error) {
+	fields := strings.Fields(input)
+	if len(fields) == 2 && !strings.ContainsAny(fields[1], "0123456789") {
+		// Drop a trailing symbol.
+		fields = fields[:1]
+	}
+	if len(fields) != 1 {
+		return nil, fmt.Errorf("airstack: invalid amount %q", input)
+	}
+	s := fields[0]
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if strings.Contains(whole, ",") {
+		if !groupedRe.MatchString(whole) {
+			return nil, fmt.Errorf("airstack: invalid amount %q", input)
+		}
+		whole = strings.ReplaceAll(whole, ",", "")
+	}
+	if whole == "" && frac == "" {
+		return nil, fmt.Errorf("airstack: invalid amount %q", input)
+	}
+	if len(frac) > decimals {
+		return nil, fmt.Errorf("airstack: amount %q has more than %d decimals", input, decimals)
+	}
+	digits := whole + frac + strings.Repeat("0", decimals-len(frac))
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return nil, fmt.Errorf("airstack: invalid amount %q", input)
+		}
+	}
+	amount, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return nil, fmt.Errorf("airstack: invalid amount %q", input)
+	}
+	if negative {
+		amount.Neg(amount)
+	}
+	return amount, nil
+}
@@ -0,0 +1,65 @@
+package airstack_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/vocdoni/go-airstack/airstack"
+)
+
+func TestFormatAmount(t *testing.T) {
+	for _, tc := range []struct {
+		amount int64
+		format airstack.AmountFormat
+		want   string
+	}{
+		{1234560000, airstack.AmountFormat{Decimals: 6, Symbol: "USDC"}, "1,234.56 USDC"},
+		{1234567890, airstack.AmountFormat{Decimals: 6, Precision: 1}, "1,234.5"},
+		{1234567890, airstack.AmountFormat{Decimals: 6, Precision: airstack.WholeUnits}, "1,234"},
+		{-5, airstack.AmountFormat{Decimals: 1}, "-0.5"},
+	} {
+		if got := airstack.FormatAmount(big.NewInt(tc.amount), tc.format); got != tc.want {
+			t.Errorf("FormatAmount(%d, %+v) = %q, want %q", tc.amount, tc.format, got, tc.want)
+		}
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		want  string
+	}{
+		{"1,234.56", "1234560000"},
+		{" 1234.56 USDC ", "1234560000"},
+		{"-0.5", "-500000"},
+		{"1,234,567", "1234567000000"},
+		{"1 000", ""},
+		{"1,5", ""},
+		{"1,2,3,4.5", ""},
+		{"1234,567", ""},
+		{",123", ""},
+		{"1.234,5", ""},
+		{"1.5 2", ""},
+		{"1.0000001", ""},
+		{"USDC", ""},
+	} {
+		got, err := airstack.ParseAmount(tc.input, 6)
+		switch {
+		case tc.want == "" && err == nil:
+			t.Errorf("ParseAmount(%q) = %s, want an error", tc.input, got)
+		case tc.want != "" && (err != nil || got.String() != tc.want):
+			t.Errorf("ParseAmount(%q) = %v, %v, want %s", tc.input, got, err, tc.want)
+		}
+	}
+}
+
+func TestFormatNativeAmount(t *testing.T) {
+	got, err := airstack.FormatNativeAmount("Base", big.NewInt(5e17), 0)
+	if err != nil || got != "0.5 ETH" {
+		t.Fatalf("got %q, %v, want 0.5 ETH", got, err)
+	}
+	if _, err := airstack.FormatNativeAmount("solana", big.NewInt(1), 0); !errors.Is(err, airstack.ErrUnknownNativeCurrency) {
+		t.Fatalf("got error %v, want ErrUnknownNativeCurrency", err)
+	}
+}
@@ -0,0 +1,42 @@
+package airstack
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// auditEntry is the JSON line written to the audit log for every request.
+type auditEntry struct {
+	Time       time.Time `json:"time"`
+	Operation  string    `json:"operation"`
+	Label      string    `json:"label,omitempty"`
+	StatusCode int       `json:"statusCode"`
+	DurationMs int64     `json:"durationMs"`
+	Bytes      int       `json:"bytes"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// audit appends info to the client audit log, if one is configured.
+func (client *AirstackClient) audit(info RequestInfo) {
+	if client.AuditLog == nil {
+		return
+	}
+	entry := auditEntry{
+		Time:       info.Time,
+		Operation:  info.Operation,
+		Label:      info.Label,
+		StatusCode: info.StatusCode,
+		DurationMs: info.Duration.Milliseconds(),
+		Bytes:      info.Bytes,
+	}
+	if info.Err != nil {
+		entry.Error = info.Err.Error()
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	client.auditMu.Lock()
+	defer client.auditMu.Unlock()
+	_, _ = client.AuditLog.Write(append(line, '\n'))
+}
@@ -0,0 +1,128 @@
+package airstack
+
+import (
+	"context"
+	"time"
+)
+
+// defaultBackfillFraction is the share of the client rate limit backfill
+// requests may use when BackfillConfig.Fraction is not set.
+const defaultBackfillFraction = 0.2
+
+// backfillKey is the context key marking backfill requests.
+type backfillKey struct{}
+
+// WithBackfill returns a context whose requests run in backfill mode: they are
+// throttled to a fraction of the client rate limit and only sent during the
+// configured off-peak windows, so low-priority jobs never starve interactive
+// traffic sharing the same API key.
+func WithBackfill(ctx context.Context) context.Context {
+	return context.WithValue(ctx, backfillKey{}, true)
+}
+
+// isBackfill reports whether ctx was created by WithBackfill.
+func isBackfill(ctx context.Context) bool {
+	backfill, _ := ctx.Value(backfillKey{}).(bool)
+	return backfill
+}
+
+// TimeWindow is a daily time range, as offsets from midnight. A window whose
+// End is before its Start spans midnight.
+type TimeWindow struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// BackfillConfig configures backfill mode.
+type BackfillConfig struct {
+	// Fraction is the share of the client Limiter rate backfill requests
+	// may use. It defaults to 0.2.
+	Fraction float64
+	// RequestsPerSecond is the backfill rate used when the client has no
+	// Limiter. Backfill requests are not throttled when it is zero.
+	RequestsPerSecond float64
+	// Windows restricts backfill requests to off-peak hours. Requests are
+	// allowed at any time when empty.
+	Windows []TimeWindow
+	// Location is the time zone of Windows, UTC when nil.
+	Location *time.Location
+}
+
+// waitBackfill blocks a backfill request until an off-peak window is open and
+// the backfill throttle allows it.
+func (client *AirstackClient) waitBackfill(ctx context.Context) error {
+	var cfg BackfillConfig
+	if client.Backfill != nil {
+		cfg = *client.Backfill
+	}
+	if delay := cfg.untilWindow(time.Now()); delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if limiter := client.backfillLimiter(cfg); limiter != nil {
+		return limiter.Wait(ctx)
+	}
+	return nil
+}
+
+// backfillLimiter returns the limiter throttling backfill requests, creating
+// it on first use, or nil when backfill requests are not throttled.
+func (client *AirstackClient) backfillLimiter(cfg BackfillConfig) *RateLimiter {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if client.backfill != nil {
+		return client.backfill
+	}
+	rate := cfg.RequestsPerSecond
+	if client.Limiter != nil {
+		fraction := cfg.Fraction
+		if fraction <= 0 {
+			fraction = defaultBackfillFraction
+		}
+		rate = client.Limiter.Rate() * fraction
+	}
+	if rate <= 0 {
+		return nil
+	}
+	client.backfill = NewRateLimiter(rate, 1)
+	return client.backfill
+}
+
+// untilWindow returns how long to wait from now until a window is open, zero
+// if one already is.
+func (cfg BackfillConfig) untilWindow(now time.Time) time.Duration {
+	if len(cfg.Windows) == 0 {
+		return 0
+	}
+	loc := cfg.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	now = now.In(loc)
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	offset := now.Sub(midnight)
+
+	wait := time.Duration(-1)
+	for _, w := range cfg.Windows {
+		open := offset >= w.Start && offset < w.End
+		if w.End < w.Start {
+			open = offset >= w.Start || offset < w.End
+		}
+		if open {
+			return 0
+		}
+		d := w.Start - offset
+		if d < 0 {
+			d += 24 * time.Hour
+		}
+		if wait < 0 || d < wait {
+			wait = d
+		}
+	}
+	return wait
+}
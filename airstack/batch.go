@@ -0,0 +1,150 @@
+package airstack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// graphQLError is an entry of the GraphQL "errors" field.
+type graphQLError struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path"`
+}
+
+// alias is the alias and variable name of the i-th selection of a batch.
+func alias(i int) string {
+	return fmt.Sprintf("a%d", i)
+}
+
+// aliasedResult is the outcome of one aliased selection.
+type aliasedResult struct {
+	Data json.RawMessage
+	Err  error
+}
+
+// aliasedRequest describes a request holding one aliased copy of selection
+// per value. Inside selection, $value refers to the value of that copy,
+// declared with varType. shared holds variables common to every copy,
+// declared with the GraphQL types in sharedTypes.
+type aliasedRequest struct {
+	operation   string
+	varType     string
+	selection   string
+	shared      map[string]interface{}
+	sharedTypes map[string]string
+}
+
+// runAliased sends req for values as a single request. A failure of the whole
+// request is returned as an error, while GraphQL errors scoped to an alias
+// only fail that alias' result.
+func (client *AirstackClient) runAliased(ctx context.Context, req aliasedRequest, values []string) ([]aliasedResult, error) {
+	var decls []string
+	var body strings.Builder
+	variables := make(map[string]interface{}, len(values)+len(req.shared))
+	for name, value := range req.shared {
+		decls = append(decls, fmt.Sprintf("$%s: %s", name, req.sharedTypes[name]))
+		variables[name] = value
+	}
+	sort.Strings(decls)
+	for i, value := range values {
+		name := alias(i)
+		decls = append(decls, fmt.Sprintf("$%s: %s", name, req.varType))
+		fmt.Fprintf(&body, "\t%s: %s\n", name, strings.ReplaceAll(req.selection, "$value", "$"+name))
+		variables[name] = value
+	}
+	query := fmt.Sprintf("query %s(%s) {\n%s}", req.operation, strings.Join(decls, ", "), body.String())
+
+	resp, err := client.ExecuteQuery(ctx, query, variables)
+	if err != nil {
+		return nil, err
+	}
+	data, aliasErrs, err := partialData(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]aliasedResult, len(values))
+	for i := range values {
+		name := alias(i)
+		results[i] = aliasedResult{Data: data[name], Err: aliasErrs[name]}
+	}
+	return results, nil
+}
+
+// fieldError is a GraphQL error scoped to the field at path.
+type fieldError struct {
+	path []string
+	err  error
+}
+
+// responseData returns the data of a response together with the GraphQL
+// errors scoped to one of its fields. Errors not scoped to a field, errors of
+// responses without data, and HTTP failures are returned as the error.
+func responseData(resp *QueryResponse) (json.RawMessage, []fieldError, error) {
+	if len(resp.Errors) == 0 {
+		if err := responseError(resp); err != nil {
+			return nil, nil, err
+		}
+		return resp.Data, nil, nil
+	}
+
+	// ExecuteQuery drops the data of responses carrying errors, so read it
+	// back from the raw envelope.
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	var gqlErrs []graphQLError
+	if json.Unmarshal(resp.Raw, &envelope) != nil || json.Unmarshal(resp.Errors, &gqlErrs) != nil {
+		return nil, nil, responseError(resp)
+	}
+	noData := len(envelope.Data) == 0 || string(envelope.Data) == "null"
+	scoped := make([]fieldError, 0, len(gqlErrs))
+	for _, e := range gqlErrs {
+		err := &ResponseError{StatusCode: resp.StatusCode, Message: e.Message}
+		if len(e.Path) == 0 || noData {
+			return nil, nil, err
+		}
+		path := make([]string, len(e.Path))
+		for i, p := range e.Path {
+			path[i] = fmt.Sprint(p)
+		}
+		scoped = append(scoped, fieldError{path: path, err: err})
+	}
+	return envelope.Data, scoped, nil
+}
+
+// partialData returns the top-level fields of a response's data together with
+// the GraphQL errors scoped to each of them, see responseData.
+func partialData(resp *QueryResponse) (map[string]json.RawMessage, map[string]error, error) {
+	raw, fieldErrs, err := responseData(resp)
+	if err != nil {
+		return nil, nil, err
+	}
+	var data map[string]json.RawMessage
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &data); err != nil {
+			return nil, nil, err
+		}
+	}
+	scoped := make(map[string]error, len(fieldErrs))
+	for _, e := range fieldErrs {
+		scoped[e.path[0]] = e.err
+	}
+	return data, scoped, nil
+}
+
+// chunks splits values into slices of at most size elements.
+func chunks(values []string, size int) [][]string {
+	var out [][]string
+	for len(values) > size {
+		out = append(out, values[:size])
+		values = values[size:]
+	}
+	if len(values) > 0 {
+		out = append(out, values)
+	}
+	return out
+}
@@ -0,0 +1,72 @@
+package airstack
+
+import (
+	"sync"
+	"time"
+)
+
+// cacheEntry is a cached value together with the time it was stored.
+type cacheEntry[V any] struct {
+	value  V
+	stored time.Time
+}
+
+// ttlCache is a concurrency-safe map of values that remembers when each value
+// was stored and which keys are currently being refreshed.
+type ttlCache[V any] struct {
+	mu         sync.Mutex
+	entries    map[string]cacheEntry[V]
+	refreshing map[string]bool
+}
+
+func newTTLCache[V any]() *ttlCache[V] {
+	return &ttlCache[V]{
+		entries:    make(map[string]cacheEntry[V]),
+		refreshing: make(map[string]bool),
+	}
+}
+
+// get returns the value stored under key and its age.
+func (c *ttlCache[V]) get(key string) (V, time.Duration, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, 0, false
+	}
+	return e.value, time.Since(e.stored), true
+}
+
+// set stores value under key.
+func (c *ttlCache[V]) set(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = cacheEntry[V]{value: value, stored: time.Now()}
+}
+
+// delete removes key from the cache.
+func (c *ttlCache[V]) delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+// startRefresh marks key as being refreshed. It returns false if a refresh
+// for key is already running.
+func (c *ttlCache[V]) startRefresh(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.refreshing[key] {
+		return false
+	}
+	c.refreshing[key] = true
+	return true
+}
+
+// endRefresh clears the refreshing mark set by startRefresh.
+func (c *ttlCache[V]) endRefresh(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.refreshing, key)
+}
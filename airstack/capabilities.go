@@ -0,0 +1,111 @@
+package airstack
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrUnsupportedChain is matched, through errors.Is, by every
+// UnsupportedChainError.
+var ErrUnsupportedChain = errors.New("airstack: unsupported chain")
+
+// UnsupportedChainError is returned by helpers called for a blockchain that
+// does not support the root they query.
+type UnsupportedChainError struct {
+	Blockchain string
+	Root       QueryType
+}
+
+func (e *UnsupportedChainError) Error() string {
+	return fmt.Sprintf("airstack: %s is not supported on blockchain %q", e.Root, e.Blockchain)
+}
+
+// Is makes errors.Is(err, ErrUnsupportedChain) match.
+func (e *UnsupportedChainError) Is(target error) bool {
+	return target == ErrUnsupportedChain
+}
+
+// Capabilities records which roots each blockchain supports, so helpers can
+// fail locally instead of sending requests Airstack would reject.
+type Capabilities struct {
+	mu    sync.RWMutex
+	roots map[string]map[QueryType]bool
+}
+
+// NewCapabilities returns an empty registry.
+func NewCapabilities() *Capabilities {
+	return &Capabilities{roots: make(map[string]map[QueryType]bool)}
+}
+
+// DefaultCapabilities returns a registry with the roots Airstack supports on
+// each of its blockchains.
+func DefaultCapabilities() *Capabilities {
+	c := NewCapabilities()
+	c.Register("ethereum", QueryTokenBalances, QueryTokenTransfers, QuerySnapshots, QueryDomains, QuerySocials)
+	c.Register("base", QueryTokenBalances, QueryTokenTransfers, QuerySnapshots)
+	c.Register("zora", QueryTokenBalances, QueryTokenTransfers, QuerySnapshots)
+	c.Register("polygon", QueryTokenBalances, QueryTokenTransfers)
+	return c
+}
+
+// Register marks roots as supported on blockchain.
+func (c *Capabilities) Register(blockchain string, roots ...QueryType) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	chain := strings.ToLower(blockchain)
+	if c.roots[chain] == nil {
+		c.roots[chain] = make(map[QueryType]bool)
+	}
+	for _, root := range roots {
+		c.roots[chain][root] = true
+	}
+}
+
+// Unregister marks roots as unsupported on blockchain.
+func (c *Capabilities) Unregister(blockchain string, roots ...QueryType) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, root := range roots {
+		delete(c.roots[strings.ToLower(blockchain)], root)
+	}
+}
+
+// Supports reports whether root can be queried on blockchain.
+func (c *Capabilities) Supports(blockchain string, root QueryType) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.roots[strings.ToLower(blockchain)][root]
+}
+
+// Blockchains returns the blockchains with at least one supported root,
+// sorted.
+func (c *Capabilities) Blockchains() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	chains := make([]string, 0, len(c.roots))
+	for chain, roots := range c.roots {
+		if len(roots) > 0 {
+			chains = append(chains, chain)
+		}
+	}
+	sort.Strings(chains)
+	return chains
+}
+
+// checkChain returns an UnsupportedChainError if root cannot be queried on
+// blockchain. A client without Capabilities uses DefaultCapabilities.
+func (client *AirstackClient) checkChain(blockchain string, root QueryType) error {
+	client.mu.Lock()
+	if client.Capabilities == nil {
+		client.Capabilities = DefaultCapabilities()
+	}
+	caps := client.Capabilities
+	client.mu.Unlock()
+	if !caps.Supports(blockchain, root) {
+		return &UnsupportedChainError{Blockchain: blockchain, Root: root}
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+package airstack
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// CensusMember is a weighted address of a census. Sources lists the original
+// entries that resolved to the member.
+type CensusMember struct {
+	Address string
+	Weight  *big.Int
+	Sources []string
+}
+
+// CensusSource produces the members of a census.
+type CensusSource interface {
+	Members(ctx context.Context) ([]CensusMember, error)
+}
+
+// UnresolvedEntry is a census entry that could not be resolved to an address.
+type UnresolvedEntry struct {
+	Entry  string
+	Reason string
+}
+
+// socialsBatchSize is the number of Farcaster ids resolved per aliased request.
+const socialsBatchSize = 50
+
+var (
+	addressRe = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+	fidRe     = regexp.MustCompile(`^(?:fc_fid:|fid:)?([0-9]+)$`)
+)
+
+const fidSelection = `Socials(input: {filter: {dappName: {_eq: farcaster}, identity: {_eq: $value}}, blockchain: ethereum}) {
+		Social {
+			userAssociatedAddresses
+		}
+	}`
+
+// MixedCensusSource is a CensusSource built from a list mixing Farcaster ids
+// ("fc_fid:1", "fid:1" or "1"), ENS names and addresses. Entries resolving to
+// overlapping addresses, such as a fid and the ENS name of one of its verified
+// addresses, are merged into a single member of weight one.
+type MixedCensusSource struct {
+	client  *AirstackClient
+	entries []string
+
+	mu         sync.Mutex
+	unresolved []UnresolvedEntry
+}
+
+// NewMixedCensusSource returns a MixedCensusSource over entries.
+func NewMixedCensusSource(client *AirstackClient, entries ...string) *MixedCensusSource {
+	return &MixedCensusSource{client: client, entries: entries}
+}
+
+// Unresolved returns the entries the last Members call could not resolve.
+// It is safe to call while Members runs, e.g. from a background job.
+func (s *MixedCensusSource) Unresolved() []UnresolvedEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]UnresolvedEntry(nil), s.unresolved...)
+}
+
+// Members resolves every entry and returns the deduplicated members, sorted by
+// address.
+func (s *MixedCensusSource) Members(ctx context.Context) ([]CensusMember, error) {
+	var unresolved []UnresolvedEntry
+	defer func() {
+		s.mu.Lock()
+		s.unresolved = unresolved
+		s.mu.Unlock()
+	}()
+	var fids, names []string
+	// resolved holds the address set of every resolved entry.
+	type resolution struct {
+		entry     string
+		addresses []string
+	}
+	var resolved []resolution
+
+	for _, entry := range s.entries {
+		e := strings.TrimSpace(entry)
+		switch {
+		case addressRe.MatchString(e):
+			addr := strings.ToLower(e)
+			resolved = append(resolved, resolution{entry: e, addresses: []string{addr}})
+		case fidRe.MatchString(e):
+			fids = append(fids, e)
+		case strings.Contains(e, "."):
+			names = append(names, e)
+		default:
+			unresolved = append(unresolved, UnresolvedEntry{Entry: e, Reason: "unrecognized entry"})
+		}
+	}
+
+	ens, err := s.client.ResolveENSBatch(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range ens {
+		switch {
+		case r.Err != nil:
+			unresolved = append(unresolved, UnresolvedEntry{Entry: r.Name, Reason: r.Err.Error()})
+		case !r.Resolved:
+			unresolved = append(unresolved, UnresolvedEntry{Entry: r.Name, Reason: "name does not resolve"})
+		default:
+			addr := strings.ToLower(r.Address)
+			resolved = append(resolved, resolution{entry: r.Name, addresses: []string{addr}})
+		}
+	}
+
+	for _, chunk := range chunks(fids, socialsBatchSize) {
+		identities := make([]string, len(chunk))
+		for i, fid := range chunk {
+			identities[i] = "fc_fid:" + fidRe.FindStringSubmatch(fid)[1]
+		}
+		aliased, err := s.client.runAliased(ctx, aliasedRequest{
+			operation: "ResolveFarcasterIds",
+			varType:   "Identity!",
+			selection: fidSelection,
+		}, identities)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			// A canceled build must not look like a partial census.
+			return nil, ctxErr
+		}
+		if err != nil {
+			for _, fid := range chunk {
+				unresolved = append(unresolved, UnresolvedEntry{Entry: fid, Reason: err.Error()})
+			}
+			continue
+		}
+		for i, fid := range chunk {
+			addrs, err := fidAddresses(aliased[i])
+			switch {
+			case err != nil:
+				unresolved = append(unresolved, UnresolvedEntry{Entry: fid, Reason: err.Error()})
+			case len(addrs) == 0:
+				unresolved = append(unresolved, UnresolvedEntry{Entry: fid, Reason: "fid has no associated address"})
+			default:
+				resolved = append(resolved, resolution{entry: fid, addresses: addrs})
+			}
+		}
+	}
+
+	// Group resolutions sharing any address, so every person counts once.
+	// Addresses and ENS names come first in resolved, so a group keeps the
+	// explicitly listed address over the ones associated to a fid.
+	group := make(map[string]int) // address -> group index
+	var groups []*CensusMember
+	var parent []int
+	find := func(i int) int {
+		for parent[i] != i {
+			i = parent[i]
+		}
+		return i
+	}
+	for _, r := range resolved {
+		id := -1
+		for _, addr := range r.addresses {
+			g, ok := group[addr]
+			if !ok {
+				continue
+			}
+			g = find(g)
+			switch {
+			case id == -1:
+				id = g
+			case g != id:
+				parent[g] = id
+				groups[id].Sources = append(groups[id].Sources, groups[g].Sources...)
+			}
+		}
+		if id == -1 {
+			id = len(groups)
+			groups = append(groups, &CensusMember{Weight: big.NewInt(1)})
+			parent = append(parent, id)
+		}
+		for _, addr := range r.addresses {
+			group[addr] = id
+		}
+		groups[id].Sources = append(groups[id].Sources, r.entry)
+		if groups[id].Address == "" {
+			groups[id].Address = r.addresses[0]
+		}
+	}
+
+	var members []CensusMember
+	for i, m := range groups {
+		if find(i) == i {
+			members = append(members, *m)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Address < members[j].Address })
+	return members, nil
+}
+
+// fidAddresses decodes the addresses associated to a Farcaster id.
+func fidAddresses(res aliasedResult) ([]string, error) {
+	if res.Err != nil {
+		return nil, res.Err
+	}
+	if len(res.Data) == 0 || string(res.Data) == "null" {
+		return nil, nil
+	}
+	var socials struct {
+		Social []Social `json:"Social"`
+	}
+	if err := json.Unmarshal(res.Data, &socials); err != nil {
+		return nil, err
+	}
+	var addrs []string
+	for _, social := range socials.Social {
+		for _, addr := range social.UserAssociatedAddresses {
+			addrs = append(addrs, strings.ToLower(addr))
+		}
+	}
+	return addrs, nil
+}
+
+// Explain reports the requests Members will send: one per chunk of ENS names
+// and one per chunk of Farcaster ids.
+func (s *MixedCensusSource) Explain() Plan {
+	var fids, names int
+	for _, entry := range s.entries {
+		e := strings.TrimSpace(entry)
+		switch {
+		case addressRe.MatchString(e):
+		case fidRe.MatchString(e):
+			fids++
+		case strings.Contains(e, "."):
+			names++
+		}
+	}
+	requests := (names+ensBatchSize-1)/ensBatchSize + (fids+socialsBatchSize-1)/socialsBatchSize
+	return Plan{
+		Operation:        "MixedCensusSource",
+		Requests:         requests,
+		Pages:            requests,
+		EstimatedCredits: float64(requests) * estimatedCreditsPerRequest,
+	}
+}
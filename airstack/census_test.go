@@ -0,0 +1,55 @@
+package airstack_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/vocdoni/go-airstack/airstack"
+	"github.com/vocdoni/go-airstack/airstacktest"
+)
+
+func TestMixedCensusSourceTrimsNames(t *testing.T) {
+	s := airstacktest.NewServer()
+	defer s.Close()
+	s.Handle("ResolveENSBatch", http.StatusOK, []byte(`{"data":{"a0":{"name":"alice.eth","resolvedAddress":"0x00000000000000000000000000000000000000AA"}}}`))
+
+	source := airstack.NewMixedCensusSource(s.Client(), " alice.eth ", "0x00000000000000000000000000000000000000aa")
+	members, err := source.Members(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unresolved := source.Unresolved(); len(unresolved) != 0 {
+		t.Fatalf("got unresolved entries %v", unresolved)
+	}
+	if len(members) != 1 || len(members[0].Sources) != 2 {
+		t.Fatalf("got members %+v, want the name and the address merged", members)
+	}
+	if name := s.Requests()[0].Variables["a0"]; name != "alice.eth" {
+		t.Fatalf("sent name %q, want it trimmed", name)
+	}
+}
+
+func TestMixedCensusSourceCanceledMidFlight(t *testing.T) {
+	s := airstacktest.NewServer()
+	defer s.Close()
+	entered, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+	s.HandleFunc("ResolveFarcasterIds", func(airstacktest.Request) airstacktest.Response {
+		entered <- struct{}{}
+		<-release
+		return airstacktest.Response{StatusCode: http.StatusOK, Body: []byte(`{"data":{}}`)}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	source := airstack.NewMixedCensusSource(s.Client(), "0x00000000000000000000000000000000000000aa", "fc_fid:1")
+	go func() {
+		<-entered
+		cancel()
+	}()
+	members, err := source.Members(ctx)
+	if !errors.Is(err, context.Canceled) || members != nil {
+		t.Fatalf("got %d members and error %v, want context.Canceled", len(members), err)
+	}
+}
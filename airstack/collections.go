@@ -0,0 +1,105 @@
+package airstack
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// CollectionBalance aggregates the balances a wallet holds in a single NFT
+// contract.
+type CollectionBalance struct {
+	Blockchain   string
+	TokenAddress string
+	// Total is the summed quantity across every token id of the contract.
+	Total *big.Int
+	// TokenIds lists the distinct token ids held, sorted.
+	TokenIds []string
+}
+
+const walletNFTBalancesQuery = `
+query GetWalletNFTBalances($identity: Identity!, $blockchain: TokenBlockchain!, $limit: Int, $cursor: String) {
+	TokenBalances(
+		input: {filter: {owner: {_eq: $identity}, tokenType: {_in: [ERC721, ERC1155]}}, blockchain: $blockchain, limit: $limit, cursor: $cursor}
+	) {
+		TokenBalance {
+			amount
+			blockchain
+			tokenAddress
+			tokenId
+		}
+		pageInfo {
+			nextCursor
+			prevCursor
+		}
+	}
+}
+`
+
+// GetCollectionBalances fetches every ERC-721 and ERC-1155 balance of identity
+// on blockchain and aggregates them per contract.
+func (client *AirstackClient) GetCollectionBalances(ctx context.Context, identity, blockchain string) ([]CollectionBalance, error) {
+	balances, err := fetchAll[TokenBalance](ctx, client, pagedQuery{
+		helper:     "GetCollectionBalances",
+		blockchain: blockchain,
+		query:      walletNFTBalancesQuery,
+		root:       QueryTokenBalances,
+		field:      "TokenBalance",
+		variables: map[string]interface{}{
+			"identity":   identity,
+			"blockchain": blockchain,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return GroupBalancesByContract(client.dedupeOwner(identity, client.filterBalances(balances))), nil
+}
+
+// GroupBalancesByContract aggregates per-token balance rows into one entry per
+// contract and blockchain, summing quantities and collecting distinct token
+// ids. Rows with amounts that are not integers count as a quantity of zero.
+// Collections are sorted by blockchain and contract address.
+func GroupBalancesByContract(balances []TokenBalance) []CollectionBalance {
+	type group struct {
+		collection CollectionBalance
+		ids        map[string]bool
+	}
+	groups := make(map[string]*group)
+	for _, b := range balances {
+		key := strings.ToLower(b.Blockchain) + ":" + strings.ToLower(b.TokenAddress)
+		g, ok := groups[key]
+		if !ok {
+			g = &group{
+				collection: CollectionBalance{
+					Blockchain:   b.Blockchain,
+					TokenAddress: strings.ToLower(b.TokenAddress),
+					Total:        new(big.Int),
+				},
+				ids: make(map[string]bool),
+			}
+			groups[key] = g
+		}
+		if amount, ok := new(big.Int).SetString(b.Amount, 10); ok {
+			g.collection.Total.Add(g.collection.Total, amount)
+		}
+		if b.TokenId != "" && !g.ids[b.TokenId] {
+			g.ids[b.TokenId] = true
+			g.collection.TokenIds = append(g.collection.TokenIds, b.TokenId)
+		}
+	}
+
+	collections := make([]CollectionBalance, 0, len(groups))
+	for _, g := range groups {
+		sort.Strings(g.collection.TokenIds)
+		collections = append(collections, g.collection)
+	}
+	sort.Slice(collections, func(i, j int) bool {
+		if collections[i].Blockchain != collections[j].Blockchain {
+			return collections[i].Blockchain < collections[j].Blockchain
+		}
+		return collections[i].TokenAddress < collections[j].TokenAddress
+	})
+	return collections
+}
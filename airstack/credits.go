@@ -0,0 +1,70 @@
+package airstack
+
+import (
+	"context"
+	"time"
+)
+
+// CreditUsage is the credit cost of a single request.
+type CreditUsage struct {
+	Time      time.Time
+	Operation string
+	Label     string
+	Credits   float64
+	// Estimated is true when Airstack did not report the cost and Credits
+	// is the flat per-request estimate.
+	Estimated bool
+}
+
+// CreditAccountant persists credit usage to an external system, such as a
+// database table, a metrics counter or a billing service, to enforce internal
+// chargeback policies. RecordCredits is called synchronously after every
+// request sent to Airstack, so slow backends should buffer.
+type CreditAccountant interface {
+	RecordCredits(ctx context.Context, usage CreditUsage) error
+}
+
+// CreditAccountantFunc adapts a function to a CreditAccountant.
+type CreditAccountantFunc func(ctx context.Context, usage CreditUsage) error
+
+// RecordCredits calls f.
+func (f CreditAccountantFunc) RecordCredits(ctx context.Context, usage CreditUsage) error {
+	return f(ctx, usage)
+}
+
+// AddCreditAccountant registers a to receive the credit usage of every
+// request. Errors returned by a are logged and do not fail the request.
+func (client *AirstackClient) AddCreditAccountant(a CreditAccountant) {
+	m := client.metricsState()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.accountants = append(m.accountants, a)
+}
+
+// creditUsage returns the credit usage of the request described by info.
+func creditUsage(info RequestInfo) CreditUsage {
+	usage := CreditUsage{
+		Time:      info.Time,
+		Operation: info.Operation,
+		Label:     info.Label,
+		Credits:   info.Credits,
+	}
+	if usage.Credits <= 0 {
+		usage.Credits = estimatedCreditsPerRequest
+		usage.Estimated = true
+	}
+	return usage
+}
+
+// account passes usage to every accountant. The accountants run detached from
+// the cancellation of ctx, so the usage of a request canceled or past its
+// deadline is still recorded.
+func (client *AirstackClient) account(ctx context.Context, accountants []CreditAccountant, usage CreditUsage) {
+	ctx = context.WithoutCancel(ctx)
+	for _, a := range accountants {
+		if err := a.RecordCredits(ctx, usage); err != nil {
+			client.logger().Warn("airstack: cannot record credit usage",
+				"operation", usage.Operation, "label", usage.Label, "error", err)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package airstack
+
+import (
+	"math/big"
+	"strings"
+)
+
+// OwnedBalance is a token balance together with the owner it belongs to.
+type OwnedBalance struct {
+	Owner string
+	TokenBalance
+}
+
+// DedupeBalances merges the rows that describe the same asset of the same
+// owner, identified by (owner, tokenAddress, tokenId), as happens when a
+// combined query counts an asset under several token types or chains. Merged
+// rows keep the largest amount, since every copy reports the same holding,
+// so aggregate counts are not inflated. The order of first appearance is
+// preserved.
+func DedupeBalances(balances []OwnedBalance) []OwnedBalance {
+	index := make(map[string]int, len(balances))
+	out := make([]OwnedBalance, 0, len(balances))
+	for _, b := range balances {
+		key := strings.ToLower(b.Owner) + "|" + strings.ToLower(b.TokenAddress) + "|" + b.TokenId
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(out)
+			out = append(out, b)
+			continue
+		}
+		if largerAmount(b.Amount, out[i].Amount) {
+			out[i].Amount = b.Amount
+			out[i].FormattedAmount = b.FormattedAmount
+		}
+	}
+	return out
+}
+
+// largerAmount reports whether the integer amount a is larger than b. Amounts
+// that are not integers are never larger.
+func largerAmount(a, b string) bool {
+	x, ok := new(big.Int).SetString(a, 10)
+	if !ok {
+		return false
+	}
+	y, ok := new(big.Int).SetString(b, 10)
+	return !ok || x.Cmp(y) > 0
+}
+
+// dedupeOwner applies DedupeBalances to the balances of a single owner when
+// the client has DeduplicateBalances set.
+func (client *AirstackClient) dedupeOwner(owner string, balances []TokenBalance) []TokenBalance {
+	if !client.DeduplicateBalances {
+		return balances
+	}
+	owned := make([]OwnedBalance, len(balances))
+	for i, b := range balances {
+		owned[i] = OwnedBalance{Owner: owner, TokenBalance: b}
+	}
+	owned = DedupeBalances(owned)
+	out := make([]TokenBalance, len(owned))
+	for i, b := range owned {
+		out[i] = b.TokenBalance
+	}
+	return out
+}
@@ -0,0 +1,135 @@
+package airstack
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Deprecation sources.
+const (
+	DeprecationSourceResponse = "response"
+	DeprecationSourceSchema   = "schema"
+)
+
+// Deprecation reports a deprecated field used by the queries of this package.
+type Deprecation struct {
+	Operation string
+	Field     string
+	Reason    string
+	// Source tells whether the deprecation was reported by a response or
+	// found through schema introspection.
+	Source    string
+	FirstSeen time.Time
+	LastSeen  time.Time
+	Count     int
+}
+
+// deprecations collects the deprecations observed by a client.
+type deprecations struct {
+	mu   sync.Mutex
+	seen map[string]*Deprecation
+}
+
+// deprecationState returns the client's deprecation tracker, creating it on
+// first use.
+func (client *AirstackClient) deprecationState() *deprecations {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if client.deprecations == nil {
+		client.deprecations = &deprecations{seen: make(map[string]*Deprecation)}
+	}
+	return client.deprecations
+}
+
+// logger returns the client logger, falling back to the default slog logger.
+func (client *AirstackClient) logger() *slog.Logger {
+	if client.Logger != nil {
+		return client.Logger
+	}
+	return slog.Default()
+}
+
+// noteDeprecation records a deprecated field, logging a warning the first
+// time it is seen.
+func (client *AirstackClient) noteDeprecation(operation, field, reason, source string) {
+	d := client.deprecationState()
+	now := time.Now()
+	key := source + "|" + operation + "|" + field
+
+	d.mu.Lock()
+	entry, ok := d.seen[key]
+	if !ok {
+		entry = &Deprecation{Operation: operation, Field: field, Reason: reason, Source: source, FirstSeen: now}
+		d.seen[key] = entry
+	}
+	entry.LastSeen = now
+	entry.Count++
+	d.mu.Unlock()
+
+	if !ok {
+		client.logger().Warn("airstack: deprecated field in use",
+			"operation", operation, "field", field, "reason", reason, "source", source)
+	}
+}
+
+// Deprecations returns the deprecations observed so far, sorted by field and
+// operation.
+func (client *AirstackClient) Deprecations() []Deprecation {
+	d := client.deprecationState()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	out := make([]Deprecation, 0, len(d.seen))
+	for _, entry := range d.seen {
+		out = append(out, *entry)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Field != out[j].Field {
+			return out[i].Field < out[j].Field
+		}
+		return out[i].Operation < out[j].Operation
+	})
+	return out
+}
+
+// CheckDeprecations introspects the live schema and records every deprecated
+// field selected by the built-in queries. It returns the deprecations found.
+func (client *AirstackClient) CheckDeprecations(ctx context.Context) ([]Deprecation, error) {
+	types, err := client.introspect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var found []Deprecation
+	for _, t := range types {
+		used, ok := builtinFields[t.Name]
+		if !ok {
+			continue
+		}
+		for _, f := range t.Fields {
+			if !f.IsDeprecated || !contains(used, f.Name) {
+				continue
+			}
+			field := t.Name + "." + f.Name
+			client.noteDeprecation("schema", field, f.DeprecationReason, DeprecationSourceSchema)
+			found = append(found, Deprecation{
+				Operation: "schema",
+				Field:     field,
+				Reason:    f.DeprecationReason,
+				Source:    DeprecationSourceSchema,
+			})
+		}
+	}
+	return found, nil
+}
+
+// contains reports whether values holds value.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
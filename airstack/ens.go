@@ -0,0 +1,80 @@
+package airstack
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// ensBatchSize is the number of names resolved per aliased request.
+const ensBatchSize = 50
+
+// ENSResult is the resolution outcome of a single ENS name. Address is empty
+// when the name does not resolve; Err is set when its lookup failed.
+type ENSResult struct {
+	Name     string
+	Address  string
+	Resolved bool
+	Err      error
+}
+
+const ensSelection = `Domain(input: {name: $value, blockchain: ethereum}) {
+		name
+		resolvedAddress
+	}`
+
+// ResolveENSBatch resolves names to addresses using chunked aliased queries.
+// Results are returned in the order of names; a failing chunk or name is
+// reported in its results instead of failing the whole batch. Only a canceled
+// context aborts the batch, returning the results gathered so far.
+func (client *AirstackClient) ResolveENSBatch(ctx context.Context, names []string) ([]ENSResult, error) {
+	results := make([]ENSResult, 0, len(names))
+	for _, chunk := range chunks(names, ensBatchSize) {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		aliased, err := client.runAliased(ctx, aliasedRequest{
+			operation: "ResolveENSBatch",
+			varType:   "String!",
+			selection: ensSelection,
+		}, chunk)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return results, ctxErr
+		}
+		if err != nil {
+			for _, name := range chunk {
+				results = append(results, ENSResult{Name: name, Err: err})
+			}
+			continue
+		}
+		for i, name := range chunk {
+			results = append(results, ensResult(name, aliased[i]))
+		}
+	}
+	return results, nil
+}
+
+// ensResult decodes the aliased Domain selection of name.
+func ensResult(name string, res aliasedResult) ENSResult {
+	result := ENSResult{Name: name, Err: res.Err}
+	if res.Err != nil || len(res.Data) == 0 || string(res.Data) == "null" {
+		return result
+	}
+	var domain struct {
+		ResolvedAddress string `json:"resolvedAddress"`
+	}
+	if err := json.Unmarshal(res.Data, &domain); err != nil {
+		result.Err = err
+		return result
+	}
+	if domain.ResolvedAddress != "" && domain.ResolvedAddress != zeroAddress {
+		result.Address = domain.ResolvedAddress
+		result.Resolved = true
+	}
+	return result
+}
+
+// ExplainENSBatch reports the cost of a ResolveENSBatch call for n names.
+// Every chunk of names counts as one page.
+func (client *AirstackClient) ExplainENSBatch(n int) Plan {
+	return pagedPlan("ResolveENSBatch", n, ensBatchSize)
+}
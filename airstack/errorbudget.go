@@ -0,0 +1,146 @@
+package airstack
+
+import (
+	"sync"
+	"time"
+)
+
+// Error budget defaults.
+const (
+	defaultErrorBudgetWindow      = 5 * time.Minute
+	defaultErrorBudgetMinRequests = 10
+)
+
+// ErrorBudget configures the alerting of an ErrorBudgetTracker.
+type ErrorBudget struct {
+	// Window is the rolling period error rates are computed over. It
+	// defaults to five minutes.
+	Window time.Duration
+	// Threshold is the failure ratio, between 0 and 1, above which an
+	// operation raises an alert.
+	Threshold float64
+	// MinRequests is the number of requests an operation needs within the
+	// window before it can raise an alert. It defaults to ten.
+	MinRequests int
+	// OnAlert is called when an operation exceeds the threshold. It is
+	// called again only after the operation recovers below it.
+	OnAlert func(Alert)
+}
+
+// Alert reports an operation exceeding its error budget.
+type Alert struct {
+	Time      time.Time
+	Operation string
+	Requests  int
+	Failures  int
+	Rate      float64
+	Window    time.Duration
+}
+
+// requestOutcome is a request seen by an ErrorBudgetTracker.
+type requestOutcome struct {
+	time   time.Time
+	failed bool
+}
+
+// ErrorBudgetTracker tracks rolling error rates per operation.
+type ErrorBudgetTracker struct {
+	budget   ErrorBudget
+	mu       sync.Mutex
+	outcomes map[string][]requestOutcome
+	alerting map[string]bool
+}
+
+// TrackErrorBudget starts tracking the error rate of every operation sent by
+// the client against budget.
+func (client *AirstackClient) TrackErrorBudget(budget ErrorBudget) *ErrorBudgetTracker {
+	if budget.Window <= 0 {
+		budget.Window = defaultErrorBudgetWindow
+	}
+	if budget.MinRequests <= 0 {
+		budget.MinRequests = defaultErrorBudgetMinRequests
+	}
+	t := &ErrorBudgetTracker{
+		budget:   budget,
+		outcomes: make(map[string][]requestOutcome),
+		alerting: make(map[string]bool),
+	}
+	client.Observe(t.observe)
+	return t
+}
+
+// observe records a request and raises an alert if its operation exceeds the
+// budget. Canceled requests are ignored.
+func (t *ErrorBudgetTracker) observe(info RequestInfo) {
+	if info.Canceled {
+		return
+	}
+	now := time.Now()
+	t.mu.Lock()
+	outcomes := append(t.prune(info.Operation, now), requestOutcome{time: now, failed: info.Err != nil})
+	t.outcomes[info.Operation] = outcomes
+	failures := countFailures(outcomes)
+	rate := float64(failures) / float64(len(outcomes))
+
+	var alert *Alert
+	switch {
+	case len(outcomes) >= t.budget.MinRequests && rate > t.budget.Threshold:
+		if !t.alerting[info.Operation] {
+			t.alerting[info.Operation] = true
+			alert = &Alert{
+				Time:      now,
+				Operation: info.Operation,
+				Requests:  len(outcomes),
+				Failures:  failures,
+				Rate:      rate,
+				Window:    t.budget.Window,
+			}
+		}
+	case rate <= t.budget.Threshold:
+		t.alerting[info.Operation] = false
+	}
+	t.mu.Unlock()
+
+	if alert != nil && t.budget.OnAlert != nil {
+		t.budget.OnAlert(*alert)
+	}
+}
+
+// prune drops the outcomes of operation older than the window.
+func (t *ErrorBudgetTracker) prune(operation string, now time.Time) []requestOutcome {
+	outcomes := t.outcomes[operation]
+	cutoff := now.Add(-t.budget.Window)
+	i := 0
+	for i < len(outcomes) && outcomes[i].time.Before(cutoff) {
+		i++
+	}
+	return outcomes[i:]
+}
+
+// Rates returns the current failure ratio of every operation seen within the
+// window.
+func (t *ErrorBudgetTracker) Rates() map[string]float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	rates := make(map[string]float64, len(t.outcomes))
+	for op := range t.outcomes {
+		outcomes := t.prune(op, now)
+		t.outcomes[op] = outcomes
+		if len(outcomes) > 0 {
+			rates[op] = float64(countFailures(outcomes)) / float64(len(outcomes))
+		}
+	}
+	return rates
+}
+
+// countFailures counts the failed outcomes.
+func countFailures(outcomes []requestOutcome) int {
+	n := 0
+	for _, o := range outcomes {
+		if o.failed {
+			n++
+		}
+	}
+	return n
+}
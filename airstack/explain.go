@@ -0,0 +1,67 @@
+package airstack
+
+import "fmt"
+
+// estimatedCreditsPerRequest is the flat credit cost assumed for every request
+// when estimating plans. Actual costs depend on query complexity.
+const estimatedCreditsPerRequest = 1.0
+
+// Plan describes the work an operation is expected to perform before it is
+// executed.
+type Plan struct {
+	Operation        string
+	Requests         int
+	Pages            int
+	EstimatedCredits float64
+	// Cached is true when the operation would be served from a local cache.
+	Cached bool
+}
+
+func (p Plan) String() string {
+	return fmt.Sprintf("%s: %d requests, %d pages, ~%.0f credits (cached: %t)",
+		p.Operation, p.Requests, p.Pages, p.EstimatedCredits, p.Cached)
+}
+
+// pagedPlan returns the plan of a paginated operation expected to return
+// items rows with the given page size.
+func pagedPlan(operation string, items, pageSize int) Plan {
+	pages := 1
+	if items > pageSize {
+		pages = (items + pageSize - 1) / pageSize
+	}
+	return Plan{
+		Operation:        operation,
+		Requests:         pages,
+		Pages:            pages,
+		EstimatedCredits: float64(pages) * estimatedCreditsPerRequest,
+	}
+}
+
+// ExplainTokenBalances reports the cost of a GetTokenBalances call.
+func (client *AirstackClient) ExplainTokenBalances(variables map[string]interface{}) Plan {
+	return Plan{
+		Operation:        "GetTokenBalances",
+		Requests:         1,
+		Pages:            1,
+		EstimatedCredits: estimatedCreditsPerRequest,
+	}
+}
+
+// ExplainTokenHolders reports the cost of a GetTokenHolders call for a token
+// expected to have about expectedRows balance rows.
+func (client *AirstackClient) ExplainTokenHolders(token Token, expectedRows int) Plan {
+	return pagedPlan("GetTokenHolders", expectedRows, client.PageSize(QueryTokenBalances))
+}
+
+// ExplainTopHolders reports the cost of a GetTopHolders call. A call served
+// from a fresh cache entry costs nothing; a stale entry is served immediately
+// but triggers a background scan, which is included in the plan.
+func (client *AirstackClient) ExplainTopHolders(token Token, expectedRows int) Plan {
+	_, age, ok := client.holdersCache().get(token.key())
+	if ok && age < client.TopHoldersTTL {
+		return Plan{Operation: "GetTopHolders", Cached: true}
+	}
+	plan := pagedPlan("GetTopHolders", expectedRows, client.PageSize(QueryTokenBalances))
+	plan.Cached = ok
+	return plan
+}
@@ -0,0 +1,249 @@
+package airstack
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// exportsNamespace is the Store namespace holding export checkpoints.
+const exportsNamespace = "exports"
+
+// ErrCheckpointMismatch is returned when a partial export file was modified
+// or deleted since its checkpoint and cannot be resumed safely. Discard the
+// checkpoint with DiscardExportCheckpoint to restart the export from scratch.
+var ErrCheckpointMismatch = errors.New("airstack: export file does not match its checkpoint")
+
+// ExportCheckpoint records the progress of an export: the cursor of the next
+// page to fetch and the length and hash of the file written so far. Export
+// is the hash of the exported query and variables, so a checkpoint is only
+// resumed by the export that wrote it.
+type ExportCheckpoint struct {
+	Export string `json:"export"`
+	Cursor string `json:"cursor"`
+	Bytes  int64  `json:"bytes"`
+	SHA256 string `json:"sha256"`
+}
+
+// csvExport describes a paginated query exported as CSV rows.
+type csvExport struct {
+	query  pagedQuery
+	header []string
+	rows   func(items json.RawMessage) ([][]string, error)
+}
+
+// ExportTokenHoldersCSV writes every balance row of token to a CSV file at
+// path, with owner, token id and amount columns. A checkpoint is stored in
+// store after every page; when an interrupted export of the same path is run
+// again, the partial file is verified against the checkpoint and the export
+// resumes appending from the next page instead of restarting. A partial file
+// no longer matching its checkpoint, or a checkpoint left by the export of
+// another token, fails the export with ErrCheckpointMismatch.
+func (client *AirstackClient) ExportTokenHoldersCSV(ctx context.Context, token Token, path string, store Store) error {
+	const helper = "ExportTokenHoldersCSV"
+	return client.exportCSV(ctx, path, store, csvExport{
+		query:  holdersQuery(helper, token),
+		header: []string{"owner", "tokenId", "amount"},
+		rows: func(items json.RawMessage) ([][]string, error) {
+			var balances []tokenHolderBalance
+			if err := json.Unmarshal(items, &balances); err != nil {
+				return nil, err
+			}
+			balances = validate(client, helper, balances)
+			rows := make([][]string, len(balances))
+			for i, b := range balances {
+				rows[i] = []string{b.address(), b.TokenId, b.Amount}
+			}
+			return rows, nil
+		},
+	})
+}
+
+// id returns the hash identifying the query, variables and columns of export.
+func (export csvExport) id() (string, error) {
+	data, err := json.Marshal([]interface{}{export.query.query, export.query.variables, export.header})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// exportCSV runs export into path, resuming from the checkpoint in store, if
+// any. The checkpoint is removed once the export completes.
+func (client *AirstackClient) exportCSV(ctx context.Context, path string, store Store, export csvExport) error {
+	if export.query.blockchain != "" {
+		if err := client.checkChain(export.query.blockchain, export.query.root); err != nil {
+			return err
+		}
+	}
+	key, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	id, err := export.id()
+	if err != nil {
+		return err
+	}
+	cp, err := loadCheckpoint(ctx, store, key)
+	if err != nil {
+		return err
+	}
+	if cp != nil && cp.Export != id {
+		return ErrCheckpointMismatch
+	}
+
+	var f *os.File
+	if cp == nil {
+		if f, err = os.Create(path); err != nil {
+			return err
+		}
+		cp = &ExportCheckpoint{Export: id}
+	} else if f, err = resumeFile(path, cp); err != nil {
+		return err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if cp.Bytes > 0 {
+		// Rehash the verified prefix so later checkpoints cover the whole file.
+		if _, err := io.Copy(hash, io.NewSectionReader(f, 0, cp.Bytes)); err != nil {
+			return err
+		}
+	}
+	counter := &countingWriter{w: io.MultiWriter(f, hash), n: cp.Bytes}
+	w := csv.NewWriter(counter)
+	if cp.Bytes == 0 {
+		if err := w.Write(export.header); err != nil {
+			return err
+		}
+	}
+
+	err = client.paginate(ctx, export.query, cp.Cursor, func(items json.RawMessage, next string) error {
+		if len(items) > 0 && string(items) != "null" {
+			rows, err := export.rows(items)
+			if err != nil {
+				return err
+			}
+			if err := w.WriteAll(rows); err != nil {
+				return err
+			}
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return err
+		}
+		if err := f.Sync(); err != nil {
+			return err
+		}
+		if next == "" {
+			return nil
+		}
+		return saveCheckpoint(ctx, store, key, &ExportCheckpoint{
+			Export: id,
+			Cursor: next,
+			Bytes:  counter.n,
+			SHA256: hex.EncodeToString(hash.Sum(nil)),
+		})
+	})
+	if err != nil {
+		return err
+	}
+	return store.Delete(ctx, exportsNamespace, key)
+}
+
+// resumeFile opens the partial export at path, verifies that its first
+// cp.Bytes bytes match the checkpoint hash and drops anything written after
+// the checkpoint.
+func resumeFile(path string, cp *ExportCheckpoint) (*os.File, error) {
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrCheckpointMismatch
+	}
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if info.Size() < cp.Bytes {
+		f.Close()
+		return nil, ErrCheckpointMismatch
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(hash, io.NewSectionReader(f, 0, cp.Bytes)); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if hex.EncodeToString(hash.Sum(nil)) != cp.SHA256 {
+		f.Close()
+		return nil, ErrCheckpointMismatch
+	}
+	if err := f.Truncate(cp.Bytes); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if _, err := f.Seek(cp.Bytes, io.SeekStart); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+// DiscardExportCheckpoint drops the checkpoint of the export to path from
+// store, so the next export to path restarts from scratch.
+func DiscardExportCheckpoint(ctx context.Context, store Store, path string) error {
+	key, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if err := store.Delete(ctx, exportsNamespace, key); err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+// loadCheckpoint returns the checkpoint stored under key, or nil if none.
+func loadCheckpoint(ctx context.Context, store Store, key string) (*ExportCheckpoint, error) {
+	value, err := store.Get(ctx, exportsNamespace, key)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cp ExportCheckpoint
+	if err := json.Unmarshal(value, &cp); err != nil {
+		return nil, err
+	}
+	return &cp, nil
+}
+
+// saveCheckpoint stores cp under key.
+func saveCheckpoint(ctx context.Context, store Store, key string, cp *ExportCheckpoint) error {
+	value, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	return store.Put(ctx, exportsNamespace, key, value)
+}
+
+// countingWriter counts the bytes written through it.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
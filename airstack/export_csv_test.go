@@ -0,0 +1,111 @@
+package airstack_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vocdoni/go-airstack/airstack"
+	"github.com/vocdoni/go-airstack/airstacktest"
+)
+
+// exportHolders exports the holders served by f to path.
+func exportHolders(t *testing.T, f *airstacktest.PaginationFixture, path string, store airstack.Store) error {
+	t.Helper()
+	_, client := serveHolders(t, f)
+	client.PageRetries = 0
+	client.SetPageSize(airstack.QueryTokenBalances, 100)
+	return client.ExportTokenHoldersCSV(context.Background(), testToken, path, store)
+}
+
+func TestExportTokenHoldersCSVResume(t *testing.T) {
+	dir := t.TempDir()
+	items := airstacktest.TokenBalanceItems(450)
+	want := filepath.Join(dir, "want.csv")
+	if err := exportHolders(t, &airstacktest.PaginationFixture{Items: items}, want, airstack.NewMemoryStore()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Interrupt the export after two pages, then resume it.
+	store := airstack.NewMemoryStore()
+	got := filepath.Join(dir, "got.csv")
+	err := exportHolders(t, &airstacktest.PaginationFixture{Items: items, RateLimitPage: 2, RateLimitTimes: 100}, got, store)
+	if err == nil {
+		t.Fatal("want the rate limited export to fail")
+	}
+	if cp, err := store.Get(context.Background(), "exports", got); err != nil || !bytes.Contains(cp, []byte(`"offset:200"`)) {
+		t.Fatalf("got checkpoint %s, %v, want one after two pages", cp, err)
+	}
+	if err := exportHolders(t, &airstacktest.PaginationFixture{Items: items}, got, store); err != nil {
+		t.Fatal(err)
+	}
+	checkSameFile(t, got, want)
+
+	// The checkpoint is removed once the export completes.
+	if _, err := store.Get(context.Background(), "exports", got); !errors.Is(err, airstack.ErrNotFound) {
+		t.Fatalf("got checkpoint error %v, want it removed", err)
+	}
+}
+
+func TestExportTokenHoldersCSVMissingPartialFile(t *testing.T) {
+	dir := t.TempDir()
+	items := airstacktest.TokenBalanceItems(450)
+	store := airstack.NewMemoryStore()
+	path := filepath.Join(dir, "holders.csv")
+	if err := exportHolders(t, &airstacktest.PaginationFixture{Items: items, RateLimitPage: 1, RateLimitTimes: 100}, path, store); err == nil {
+		t.Fatal("want the rate limited export to fail")
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := exportHolders(t, &airstacktest.PaginationFixture{Items: items}, path, store); !errors.Is(err, airstack.ErrCheckpointMismatch) {
+		t.Fatalf("got error %v, want ErrCheckpointMismatch", err)
+	}
+	if err := airstack.DiscardExportCheckpoint(context.Background(), store, path); err != nil {
+		t.Fatal(err)
+	}
+	if err := exportHolders(t, &airstacktest.PaginationFixture{Items: items}, path, store); err != nil {
+		t.Fatal(err)
+	}
+	want := filepath.Join(dir, "want.csv")
+	if err := exportHolders(t, &airstacktest.PaginationFixture{Items: items}, want, airstack.NewMemoryStore()); err != nil {
+		t.Fatal(err)
+	}
+	checkSameFile(t, path, want)
+}
+
+// checkSameFile fails unless the files at got and want are identical.
+func checkSameFile(t *testing.T, got, want string) {
+	t.Helper()
+	gotData, err := os.ReadFile(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantData, err := os.ReadFile(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(gotData, wantData) {
+		t.Fatalf("got %d bytes:\n%s\nwant %d bytes:\n%s", len(gotData), gotData, len(wantData), wantData)
+	}
+}
+
+func TestExportTokenHoldersCSVOtherToken(t *testing.T) {
+	store := airstack.NewMemoryStore()
+	path := filepath.Join(t.TempDir(), "holders.csv")
+	items := airstacktest.TokenBalanceItems(450)
+	if err := exportHolders(t, &airstacktest.PaginationFixture{Items: items, RateLimitPage: 1, RateLimitTimes: 100}, path, store); err == nil {
+		t.Fatal("want the rate limited export to fail")
+	}
+
+	_, client := serveHolders(t, &airstacktest.PaginationFixture{Items: items})
+	other := airstack.Token{Address: "0x0000000000000000000000000000000000000002", Blockchain: "ethereum"}
+	err := client.ExportTokenHoldersCSV(context.Background(), other, path, store)
+	if !errors.Is(err, airstack.ErrCheckpointMismatch) {
+		t.Fatalf("got error %v, want ErrCheckpointMismatch", err)
+	}
+}
@@ -0,0 +1,19 @@
+package airstack
+
+import "context"
+
+// TokenHolderBalance exposes the holder rows to the external tests.
+type TokenHolderBalance = tokenHolderBalance
+
+// FetchHolderRows runs fetchAll over the holders query of token, returning
+// the rows as delivered by the pagination.
+func FetchHolderRows(ctx context.Context, client *AirstackClient, token Token) ([]TokenHolderBalance, error) {
+	return fetchAll[tokenHolderBalance](ctx, client, holdersQuery("GetTokenHolders", token))
+}
+
+// Groups returns the number of live request groups of client.
+func Groups(client *AirstackClient) int {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	return len(client.groups)
+}
@@ -0,0 +1,56 @@
+package airstack
+
+import "encoding/json"
+
+// Extensions is the typed form of the GraphQL "extensions" field of a
+// response. Keys without a typed counterpart are kept in Other.
+type Extensions struct {
+	Cost         *QueryCost
+	Tracing      json.RawMessage
+	Deprecations []DeprecationHint
+	Other        map[string]json.RawMessage
+}
+
+// QueryCost is the cost information Airstack reports for a request.
+type QueryCost struct {
+	Credits    float64 `json:"credits"`
+	Complexity int     `json:"complexity"`
+}
+
+// DeprecationHint reports a deprecated field used by a query.
+type DeprecationHint struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// parseExtensions decodes the raw extensions field of a response. It returns
+// nil when the field is absent or cannot be decoded.
+func parseExtensions(raw json.RawMessage) *Extensions {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil
+	}
+	ext := &Extensions{Other: make(map[string]json.RawMessage)}
+	for key, value := range fields {
+		var err error
+		switch key {
+		case "cost":
+			ext.Cost = &QueryCost{}
+			err = json.Unmarshal(value, ext.Cost)
+		case "tracing":
+			ext.Tracing = value
+		case "deprecations":
+			err = json.Unmarshal(value, &ext.Deprecations)
+		default:
+			ext.Other[key] = value
+		}
+		if err != nil {
+			// Keep undecodable known keys available in their raw form.
+			ext.Other[key] = value
+		}
+	}
+	return ext
+}
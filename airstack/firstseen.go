@@ -0,0 +1,105 @@
+package airstack
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// FirstSeenChains are the blockchains scanned by GetWalletFirstSeen.
+var FirstSeenChains = []string{"ethereum", "base", "zora", "polygon"}
+
+// WalletAge is the earliest observed activity of a wallet.
+type WalletAge struct {
+	Wallet string
+	// Found is false when no transfer involving the wallet was found.
+	Found      bool
+	FirstSeen  time.Time
+	Blockchain string
+}
+
+// Age returns how long ago the wallet was first seen.
+func (a WalletAge) Age() time.Duration {
+	if !a.Found {
+		return 0
+	}
+	return time.Since(a.FirstSeen)
+}
+
+// firstSeenQuery builds a query selecting the oldest inbound and outbound
+// transfer of $wallet on every chain, using one aliased root per chain and
+// direction.
+func firstSeenQuery(chains []string) string {
+	var decls, body strings.Builder
+	decls.WriteString("$wallet: Identity!")
+	for i := range chains {
+		fmt.Fprintf(&decls, ", $chain%d: TokenBlockchain!", i)
+		for _, dir := range []string{"from", "to"} {
+			fmt.Fprintf(&body, `	%s%d: TokenTransfers(
+		input: {filter: {%s: {_eq: $wallet}}, blockchain: $chain%d, limit: 1, order: {blockTimestamp: ASC}}
+	) {
+		TokenTransfer {
+			blockTimestamp
+		}
+	}
+`, dir, i, dir, i)
+		}
+	}
+	return fmt.Sprintf("query GetWalletFirstSeen(%s) {\n%s}", decls.String(), body.String())
+}
+
+// firstSeenCache returns the client's first-seen cache, creating it on first
+// use.
+func (client *AirstackClient) firstSeenCache() *ttlCache[WalletAge] {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if client.firstSeen == nil {
+		client.firstSeen = newTTLCache[WalletAge]()
+	}
+	return client.firstSeen
+}
+
+// GetWalletFirstSeen returns the timestamp of the earliest token transfer sent
+// or received by wallet across FirstSeenChains, to support account-age based
+// sybil filters. Wallets with activity are cached for the life of the client
+// since their first activity never changes.
+func (client *AirstackClient) GetWalletFirstSeen(ctx context.Context, wallet string) (WalletAge, error) {
+	wallet = strings.ToLower(wallet)
+	cache := client.firstSeenCache()
+	if age, _, ok := cache.get(wallet); ok {
+		return age, nil
+	}
+
+	chains := FirstSeenChains
+	variables := map[string]interface{}{"wallet": wallet}
+	for i, chain := range chains {
+		variables[fmt.Sprintf("chain%d", i)] = chain
+	}
+	var data map[string]*struct {
+		TokenTransfer []struct {
+			BlockTimestamp time.Time `json:"blockTimestamp"`
+		} `json:"TokenTransfer"`
+	}
+	if err := client.query(ctx, firstSeenQuery(chains), variables, &data); err != nil {
+		return WalletAge{}, err
+	}
+
+	age := WalletAge{Wallet: wallet}
+	for i, chain := range chains {
+		for _, dir := range []string{"from", "to"} {
+			root := data[fmt.Sprintf("%s%d", dir, i)]
+			if root == nil || len(root.TokenTransfer) == 0 {
+				continue
+			}
+			ts := root.TokenTransfer[0].BlockTimestamp
+			if !age.Found || ts.Before(age.FirstSeen) {
+				age.Found, age.FirstSeen, age.Blockchain = true, ts, chain
+			}
+		}
+	}
+	if age.Found {
+		cache.set(wallet, age)
+	}
+	return age, nil
+}
@@ -0,0 +1,180 @@
+package airstack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+)
+
+// followersNamespace is the Store namespace holding follower samples.
+const followersNamespace = "followers"
+
+// Follower watcher defaults.
+const (
+	defaultFollowerInterval   = time.Hour
+	defaultMaxFollowerSamples = 10000
+)
+
+// FollowerSample is the follower count of a profile observed at a given time.
+type FollowerSample struct {
+	Time      time.Time `json:"time"`
+	DappName  string    `json:"dappName"`
+	Followers int       `json:"followers"`
+}
+
+// FollowerGrowth is the follower history of a profile on a single dapp.
+type FollowerGrowth struct {
+	Identity string
+	DappName string
+	Samples  []FollowerSample
+	// Change is the follower difference between the first and last sample.
+	Change int
+}
+
+// FollowerWatcher polls the follower counts of tracked profiles and records
+// them in a Store, building a time series of follower growth.
+type FollowerWatcher struct {
+	// OnError, when set, receives the error of every failed poll of Run.
+	OnError func(error)
+	// MaxSamples caps the samples kept per profile, dropping the oldest
+	// ones first.
+	MaxSamples int
+
+	client   *AirstackClient
+	store    Store
+	interval time.Duration
+	profiles []string
+}
+
+// NewFollowerWatcher returns a watcher recording the follower counts of the
+// given identities into store every interval. A non-positive interval polls
+// every hour.
+func NewFollowerWatcher(client *AirstackClient, store Store, interval time.Duration, profiles ...string) *FollowerWatcher {
+	if interval <= 0 {
+		interval = defaultFollowerInterval
+	}
+	return &FollowerWatcher{
+		MaxSamples: defaultMaxFollowerSamples,
+		client:     client,
+		store:      store,
+		interval:   interval,
+		profiles:   profiles,
+	}
+}
+
+// Run polls the tracked profiles until ctx is done. Poll errors are passed to
+// OnError, or logged by the client logger, and do not stop the watcher; Run
+// returns ctx.Err() when it exits.
+func (w *FollowerWatcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		if err := w.Poll(ctx); err != nil && ctx.Err() == nil {
+			if w.OnError != nil {
+				w.OnError(err)
+			} else {
+				w.client.logger().Warn("airstack: follower poll failed", "error", err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll records one follower sample per dapp for every tracked profile. A
+// sample repeating the count of the two previous samples of its dapp replaces
+// the last one, so plateaus are stored as their first and last samples.
+func (w *FollowerWatcher) Poll(ctx context.Context) error {
+	var errs []error
+	now := time.Now().UTC()
+	for _, identity := range w.profiles {
+		socials, err := w.client.GetSocials(ctx, identity)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		samples, err := w.samples(ctx, identity)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, s := range socials {
+			samples = appendSample(samples, FollowerSample{Time: now, DappName: s.DappName, Followers: s.FollowerCount})
+		}
+		if w.MaxSamples > 0 && len(samples) > w.MaxSamples {
+			samples = samples[len(samples)-w.MaxSamples:]
+		}
+		value, err := json.Marshal(samples)
+		if err == nil {
+			err = w.store.Put(ctx, followersNamespace, identity, value)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// appendSample appends sample to samples, replacing the last sample of its
+// dapp instead when the two previous ones already hold the same count.
+func appendSample(samples []FollowerSample, sample FollowerSample) []FollowerSample {
+	var last []int
+	for i := len(samples) - 1; i >= 0 && len(last) < 2; i-- {
+		if samples[i].DappName == sample.DappName {
+			last = append(last, i)
+		}
+	}
+	if len(last) == 2 && samples[last[0]].Followers == sample.Followers && samples[last[1]].Followers == sample.Followers {
+		samples = append(samples[:last[0]], samples[last[0]+1:]...)
+	}
+	return append(samples, sample)
+}
+
+// samples loads the recorded samples of identity.
+func (w *FollowerWatcher) samples(ctx context.Context, identity string) ([]FollowerSample, error) {
+	value, err := w.store.Get(ctx, followersNamespace, identity)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var samples []FollowerSample
+	if err := json.Unmarshal(value, &samples); err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
+
+// Growth returns the follower history of identity since the given time, one
+// entry per dapp sorted by dapp name.
+func (w *FollowerWatcher) Growth(ctx context.Context, identity string, since time.Time) ([]FollowerGrowth, error) {
+	samples, err := w.samples(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+	byDapp := make(map[string]*FollowerGrowth)
+	for _, s := range samples {
+		if s.Time.Before(since) {
+			continue
+		}
+		g, ok := byDapp[s.DappName]
+		if !ok {
+			g = &FollowerGrowth{Identity: identity, DappName: s.DappName}
+			byDapp[s.DappName] = g
+		}
+		g.Samples = append(g.Samples, s)
+	}
+	growth := make([]FollowerGrowth, 0, len(byDapp))
+	for _, g := range byDapp {
+		g.Change = g.Samples[len(g.Samples)-1].Followers - g.Samples[0].Followers
+		growth = append(growth, *g)
+	}
+	sort.Slice(growth, func(i, j int) bool { return growth[i].DappName < growth[j].DappName })
+	return growth, nil
+}
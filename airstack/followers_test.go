@@ -0,0 +1,59 @@
+package airstack_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/vocdoni/go-airstack/airstack"
+	"github.com/vocdoni/go-airstack/airstacktest"
+)
+
+func TestFollowerWatcherCompactsPlateaus(t *testing.T) {
+	s := airstacktest.NewServer()
+	defer s.Close()
+	counts := []int{10, 12, 12, 12, 12, 15}
+	polls := 0
+	s.HandleFunc("GetSocials", func(airstacktest.Request) airstacktest.Response {
+		body := fmt.Sprintf(`{"data":{"Socials":{"Social":[{"dappName":"farcaster","followerCount":%d}]}}}`, counts[polls])
+		polls++
+		return airstacktest.Response{StatusCode: http.StatusOK, Body: []byte(body)}
+	})
+
+	w := airstack.NewFollowerWatcher(s.Client(), airstack.NewMemoryStore(), 0, "alice.eth")
+	for range counts {
+		if err := w.Poll(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	growth, err := w.Growth(context.Background(), "alice.eth", time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []int
+	for _, sample := range growth[0].Samples {
+		got = append(got, sample.Followers)
+	}
+	if fmt.Sprint(got) != "[10 12 12 15]" || growth[0].Change != 5 {
+		t.Fatalf("got samples %v and change %d, want [10 12 12 15] and 5", got, growth[0].Change)
+	}
+}
+
+func TestFollowerWatcherReportsErrors(t *testing.T) {
+	s := airstacktest.NewServer()
+	defer s.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	w := airstack.NewFollowerWatcher(s.Client(), airstack.NewMemoryStore(), 0, "alice.eth")
+	w.OnError = func(err error) {
+		if err != nil {
+			cancel()
+		}
+	}
+	if err := w.Run(ctx); err != context.Canceled {
+		t.Fatalf("Run returned %v, want the poll error to reach OnError", err)
+	}
+}
@@ -0,0 +1,232 @@
+package airstack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sync"
+)
+
+// Bulk gating batch size and concurrency.
+const (
+	gateBatchSize = 50
+	gateWorkers   = 4
+)
+
+// TokenCriterion is the token an identity must hold to be eligible.
+// MinAmount is the minimum raw balance required; nil requires any positive
+// balance.
+type TokenCriterion struct {
+	Token     Token
+	MinAmount *big.Int
+}
+
+// GateResult is the eligibility of a single identity. Err is set when its
+// balance could not be determined, in which case Eligible is false.
+type GateResult struct {
+	Identity string
+	Eligible bool
+	Err      error
+}
+
+const gateSelection = `TokenBalances(
+		input: {filter: {owner: {_eq: $value}, tokenAddress: {_eq: $tokenAddress}}, blockchain: $blockchain, limit: 50}
+	) {
+		TokenBalance {
+			amount
+		}
+		pageInfo {
+			nextCursor
+		}
+	}`
+
+// gateRemainderQuery pages the balances of an owner left out of the first
+// page of its gateSelection copy.
+const gateRemainderQuery = `
+query HasTokenRemainder($identity: Identity!, $tokenAddress: Address!, $blockchain: TokenBlockchain!, $limit: Int, $cursor: String) {
+	TokenBalances(
+		input: {filter: {owner: {_eq: $identity}, tokenAddress: {_eq: $tokenAddress}}, blockchain: $blockchain, limit: $limit, cursor: $cursor}
+	) {
+		TokenBalance {
+			amount
+		}
+		pageInfo {
+			nextCursor
+			prevCursor
+		}
+	}
+}
+`
+
+// errGateReached stops the pagination of a remainder once the minimum amount
+// is reached.
+var errGateReached = errors.New("airstack: gate amount reached")
+
+// HasTokenBatch checks whether each identity satisfies criterion, sending
+// aliased batches of identities through the client rate limiter. The balance
+// rows of identities not fitting in their first page are paged individually
+// until the minimum amount is reached. Results are
+// streamed as soon as each batch completes, in no particular order, and the
+// channel is closed once every identity has been checked or ctx is done.
+func (client *AirstackClient) HasTokenBatch(ctx context.Context, identities []string, criterion TokenCriterion) <-chan GateResult {
+	results := make(chan GateResult)
+	if err := client.checkChain(criterion.Token.Blockchain, QueryTokenBalances); err != nil {
+		go func() {
+			defer close(results)
+			for _, identity := range identities {
+				select {
+				case results <- GateResult{Identity: identity, Err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		return results
+	}
+	batches := make(chan []string)
+	req := aliasedRequest{
+		operation: "HasTokenBatch",
+		varType:   "Identity!",
+		selection: gateSelection,
+		shared: map[string]interface{}{
+			"tokenAddress": criterion.Token.Address,
+			"blockchain":   criterion.Token.Blockchain,
+		},
+		sharedTypes: map[string]string{
+			"tokenAddress": "Address!",
+			"blockchain":   "TokenBlockchain!",
+		},
+	}
+	minAmount := criterion.MinAmount
+	if minAmount == nil {
+		minAmount = big.NewInt(1)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < gateWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range batches {
+				for _, res := range client.gateBatch(ctx, req, batch, minAmount) {
+					select {
+					case results <- res:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}()
+	}
+	go func() {
+		defer close(results)
+		defer wg.Wait()
+		defer close(batches)
+		for _, batch := range chunks(identities, gateBatchSize) {
+			select {
+			case batches <- batch:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return results
+}
+
+// gateBatch checks a single batch of identities against minAmount.
+func (client *AirstackClient) gateBatch(ctx context.Context, req aliasedRequest, batch []string, minAmount *big.Int) []GateResult {
+	results := make([]GateResult, len(batch))
+	aliased, err := client.runAliased(ctx, req, batch)
+	for i, identity := range batch {
+		results[i].Identity = identity
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		if aliased[i].Err != nil {
+			results[i].Err = aliased[i].Err
+			continue
+		}
+		total, next, err := gateBalance(aliased[i].Data)
+		if err == nil && next != "" && total.Cmp(minAmount) < 0 {
+			err = client.gateRemainder(ctx, req, identity, next, total, minAmount)
+		}
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		results[i].Eligible = total.Cmp(minAmount) >= 0
+	}
+	return results
+}
+
+// gateRemainder adds to total the balances of identity following cursor,
+// stopping as soon as total reaches minAmount.
+func (client *AirstackClient) gateRemainder(ctx context.Context, req aliasedRequest, identity, cursor string, total, minAmount *big.Int) error {
+	q := pagedQuery{
+		helper:     req.operation,
+		blockchain: req.shared["blockchain"].(string),
+		query:      gateRemainderQuery,
+		root:       QueryTokenBalances,
+		field:      "TokenBalance",
+		variables: map[string]interface{}{
+			"identity":     identity,
+			"tokenAddress": req.shared["tokenAddress"],
+			"blockchain":   req.shared["blockchain"],
+		},
+	}
+	err := client.paginate(ctx, q, cursor, func(items json.RawMessage, _ string) error {
+		if len(items) == 0 || string(items) == "null" {
+			return nil
+		}
+		var balances []TokenBalance
+		if err := json.Unmarshal(items, &balances); err != nil {
+			return err
+		}
+		total.Add(total, sumBalances(balances))
+		if total.Cmp(minAmount) >= 0 {
+			return errGateReached
+		}
+		return nil
+	})
+	if errors.Is(err, errGateReached) {
+		return nil
+	}
+	return err
+}
+
+// gateBalance sums the balances of an aliased TokenBalances selection and
+// returns the cursor of the rows left out of it, if any.
+func gateBalance(data json.RawMessage) (*big.Int, string, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return new(big.Int), "", nil
+	}
+	var balances struct {
+		TokenBalance []TokenBalance `json:"TokenBalance"`
+		PageInfo     pageInfo       `json:"pageInfo"`
+	}
+	if err := json.Unmarshal(data, &balances); err != nil {
+		return nil, "", err
+	}
+	return sumBalances(balances.TokenBalance), balances.PageInfo.NextCursor, nil
+}
+
+// sumBalances returns the total raw amount of balances.
+func sumBalances(balances []TokenBalance) *big.Int {
+	total := new(big.Int)
+	for _, b := range balances {
+		if amount, ok := new(big.Int).SetString(b.Amount, 10); ok {
+			total.Add(total, amount)
+		}
+	}
+	return total
+}
+
+// ExplainHasTokenBatch reports the cost of a HasTokenBatch call for n
+// identities. Every batch of identities counts as one page; identities
+// holding more than 50 ids of the token below the minimum amount cost extra
+// pages that cannot be known in advance.
+func (client *AirstackClient) ExplainHasTokenBatch(n int) Plan {
+	return pagedPlan("HasTokenBatch", n, gateBatchSize)
+}
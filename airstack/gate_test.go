@@ -0,0 +1,41 @@
+package airstack_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/vocdoni/go-airstack/airstack"
+	"github.com/vocdoni/go-airstack/airstacktest"
+)
+
+func TestHasTokenBatchPagesRemainder(t *testing.T) {
+	// The owner holds 60 ids of an ERC-721, 50 of them in the aliased page.
+	items := make([]json.RawMessage, 60)
+	for i := range items {
+		items[i] = json.RawMessage(`{"amount":"1"}`)
+	}
+	first := fmt.Sprintf(`{"data":{"a0":{"TokenBalance":[%s],"pageInfo":{"nextCursor":"offset:50"}}}}`,
+		strings.TrimSuffix(strings.Repeat(`{"amount":"1"},`, 50), ","))
+
+	s := airstacktest.NewServer()
+	defer s.Close()
+	s.Handle("HasTokenBatch", http.StatusOK, []byte(first))
+	s.HandleFunc("HasTokenRemainder", (&airstacktest.PaginationFixture{Root: "TokenBalances", Field: "TokenBalance", Items: items}).Handler())
+
+	for _, tc := range []struct {
+		min      int64
+		eligible bool
+	}{{60, true}, {61, false}} {
+		criterion := airstack.TokenCriterion{Token: testToken, MinAmount: big.NewInt(tc.min)}
+		for res := range s.Client().HasTokenBatch(context.Background(), []string{"alice.eth"}, criterion) {
+			if res.Err != nil || res.Eligible != tc.eligible {
+				t.Errorf("min %d: got eligible %v, error %v, want %v", tc.min, res.Eligible, res.Err, tc.eligible)
+			}
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package airstack
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// GraphNode is a node of a connection graph.
+type GraphNode struct {
+	ID    string
+	Label string
+}
+
+// GraphEdge is a directed edge of a connection graph.
+type GraphEdge struct {
+	Source string
+	Target string
+	Label  string
+}
+
+// Graph is a directed connection graph, such as who follows whom.
+type Graph struct {
+	Nodes []GraphNode
+	Edges []GraphEdge
+}
+
+// graphBatchSize is the number of tracked identities resolved per aliased
+// request by BuildFollowerGraph.
+const graphBatchSize = 50
+
+// walletRef is the identity and addresses of a wallet.
+type walletRef struct {
+	Identity  string   `json:"identity"`
+	Addresses []string `json:"addresses"`
+}
+
+// id returns the lowercased first address of the wallet, or its identity when
+// it has no address.
+func (w walletRef) id() string {
+	if len(w.Addresses) > 0 {
+		return strings.ToLower(w.Addresses[0])
+	}
+	return strings.ToLower(w.Identity)
+}
+
+// follower is a SocialFollowers row.
+type follower struct {
+	FollowerAddress walletRef `json:"followerAddress"`
+}
+
+const graphNodeSelection = `Wallet(input: {identity: $value, blockchain: ethereum}) {
+		identity
+		addresses
+	}`
+
+const socialFollowersQuery = `
+query GetFollowers($identity: Identity!, $dappName: SocialDappName!, $limit: Int, $cursor: String) {
+	SocialFollowers(
+		input: {filter: {identity: {_eq: $identity}, dappName: {_eq: $dappName}}, blockchain: ALL, limit: $limit, cursor: $cursor}
+	) {
+		Follower {
+			followerAddress {
+				identity
+				addresses
+			}
+		}
+		pageInfo {
+			nextCursor
+			prevCursor
+		}
+	}
+}
+`
+
+// GetFollowers returns the identities following identity on dappName, e.g.
+// "farcaster" or "lens".
+func (client *AirstackClient) GetFollowers(ctx context.Context, identity, dappName string) ([]string, error) {
+	return client.getFollowers(ctx, "GetFollowers", identity, dappName)
+}
+
+// getFollowers implements GetFollowers on behalf of helper.
+func (client *AirstackClient) getFollowers(ctx context.Context, helper, identity, dappName string) ([]string, error) {
+	rows, err := fetchAll[follower](ctx, client, pagedQuery{
+		helper: helper,
+		query:  socialFollowersQuery,
+		root:   QuerySocialFollowers,
+		field:  "Follower",
+		variables: map[string]interface{}{
+			"identity": identity,
+			"dappName": dappName,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	followers := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if id := row.FollowerAddress.id(); id != "" {
+			followers = append(followers, id)
+		}
+	}
+	return followers, nil
+}
+
+// BuildFollowerGraph returns the graph of the followers of every identity on
+// dappName, with an edge from each follower to the identity it follows.
+// Nodes are identified by wallet address, so a tracked identity following
+// another one is a single node, labeled with the identity as given.
+func (client *AirstackClient) BuildFollowerGraph(ctx context.Context, dappName string, identities ...string) (*Graph, error) {
+	ids, err := client.graphNodeIDs(ctx, identities)
+	if err != nil {
+		return nil, err
+	}
+	labels := make(map[string]string)
+	edges := make(map[GraphEdge]bool)
+	graph := &Graph{}
+	for _, identity := range identities {
+		target := ids[identity]
+		labels[target] = identity
+	}
+	for _, identity := range identities {
+		followers, err := client.getFollowers(ctx, "BuildFollowerGraph", identity, dappName)
+		if err != nil {
+			return nil, err
+		}
+		target := ids[identity]
+		for _, f := range followers {
+			if _, ok := labels[f]; !ok {
+				labels[f] = f
+			}
+			edge := GraphEdge{Source: f, Target: target, Label: "follows"}
+			if !edges[edge] {
+				edges[edge] = true
+				graph.Edges = append(graph.Edges, edge)
+			}
+		}
+	}
+	for id, label := range labels {
+		graph.Nodes = append(graph.Nodes, GraphNode{ID: id, Label: label})
+	}
+	sort.Slice(graph.Nodes, func(i, j int) bool { return graph.Nodes[i].ID < graph.Nodes[j].ID })
+	return graph, nil
+}
+
+// graphNodeIDs resolves identities to the node IDs their followers are
+// identified with, the first address of their wallet.
+func (client *AirstackClient) graphNodeIDs(ctx context.Context, identities []string) (map[string]string, error) {
+	ids := make(map[string]string, len(identities))
+	for _, chunk := range chunks(identities, graphBatchSize) {
+		aliased, err := client.runAliased(ctx, aliasedRequest{
+			operation: "ResolveGraphNodes",
+			varType:   "Identity!",
+			selection: graphNodeSelection,
+		}, chunk)
+		if err != nil {
+			return nil, err
+		}
+		for i, identity := range chunk {
+			if aliased[i].Err != nil {
+				return nil, aliased[i].Err
+			}
+			wallet := walletRef{Identity: identity}
+			if len(aliased[i].Data) > 0 && string(aliased[i].Data) != "null" {
+				if err := json.Unmarshal(aliased[i].Data, &wallet); err != nil {
+					return nil, err
+				}
+			}
+			if ids[identity] = wallet.id(); ids[identity] == "" {
+				ids[identity] = strings.ToLower(identity)
+			}
+		}
+	}
+	return ids, nil
+}
+
+// dotQuote quotes s as a DOT identifier.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// WriteDOT renders g in Graphviz DOT format.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph airstack {\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&b, "\t%s [label=%s];\n", dotQuote(n.ID), dotQuote(n.Label))
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&b, "\t%s -> %s [label=%s];\n", dotQuote(e.Source), dotQuote(e.Target), dotQuote(e.Label))
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// graphML is the GraphML document written by WriteGraphML.
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   struct {
+		ID          string        `xml:"id,attr"`
+		EdgeDefault string        `xml:"edgedefault,attr"`
+		Nodes       []graphMLNode `xml:"node"`
+		Edges       []graphMLEdge `xml:"edge"`
+	} `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+// WriteGraphML renders g in GraphML format, readable by Gephi.
+func (g *Graph) WriteGraphML(w io.Writer) error {
+	doc := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "label", For: "node", AttrName: "label", AttrType: "string"},
+			{ID: "edgeLabel", For: "edge", AttrName: "label", AttrType: "string"},
+		},
+	}
+	doc.Graph.ID = "airstack"
+	doc.Graph.EdgeDefault = "directed"
+	for _, n := range g.Nodes {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{ID: n.ID, Data: []graphMLData{{Key: "label", Value: n.Label}}})
+	}
+	for _, e := range g.Edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{Source: e.Source, Target: e.Target, Data: []graphMLData{{Key: "edgeLabel", Value: e.Label}}})
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
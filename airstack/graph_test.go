@@ -0,0 +1,50 @@
+package airstack_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/vocdoni/go-airstack/airstack"
+	"github.com/vocdoni/go-airstack/airstacktest"
+)
+
+func TestBuildFollowerGraphConnectsTrackedIdentities(t *testing.T) {
+	s := airstacktest.NewServer()
+	defer s.Close()
+	s.Handle("ResolveGraphNodes", http.StatusOK, []byte(`{"data":{
+		"a0": {"identity": "vitalik.eth", "addresses": ["0xAA"]},
+		"a1": {"identity": "fc_fid:1", "addresses": ["0xBB"]}
+	}}`))
+	followers := map[string][]string{
+		"vitalik.eth": {"0xBB", "0xCC", "0xCC"},
+		"fc_fid:1":    {"0xAA"},
+	}
+	s.HandleFunc("GetFollowers", func(req airstacktest.Request) airstacktest.Response {
+		var rows []string
+		for _, addr := range followers[req.Variables["identity"].(string)] {
+			rows = append(rows, fmt.Sprintf(`{"followerAddress":{"identity":%q,"addresses":[%q]}}`, addr, addr))
+		}
+		body := fmt.Sprintf(`{"data":{"SocialFollowers":{"Follower":[%s],"pageInfo":{}}}}`, strings.Join(rows, ","))
+		return airstacktest.Response{StatusCode: http.StatusOK, Body: []byte(body)}
+	})
+
+	graph, err := s.Client().BuildFollowerGraph(context.Background(), "farcaster", "vitalik.eth", "fc_fid:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantNodes := []airstack.GraphNode{{ID: "0xaa", Label: "vitalik.eth"}, {ID: "0xbb", Label: "fc_fid:1"}, {ID: "0xcc", Label: "0xcc"}}
+	if fmt.Sprint(graph.Nodes) != fmt.Sprint(wantNodes) {
+		t.Errorf("got nodes %v, want %v", graph.Nodes, wantNodes)
+	}
+	wantEdges := []airstack.GraphEdge{
+		{Source: "0xbb", Target: "0xaa", Label: "follows"},
+		{Source: "0xcc", Target: "0xaa", Label: "follows"},
+		{Source: "0xaa", Target: "0xbb", Label: "follows"},
+	}
+	if fmt.Sprint(graph.Edges) != fmt.Sprint(wantEdges) {
+		t.Errorf("got edges %v, want %v", graph.Edges, wantEdges)
+	}
+}
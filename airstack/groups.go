@@ -0,0 +1,122 @@
+package airstack
+
+import (
+	"context"
+	"sync"
+)
+
+// requestGroup is a set of requests that can be canceled together. refs,
+// guarded by the client mutex, counts the WithGroup contexts not yet
+// released.
+type requestGroup struct {
+	tag      string
+	ctx      context.Context
+	cancel   context.CancelFunc
+	refs     int
+	mu       sync.Mutex
+	inFlight int
+}
+
+// groupKey is the context key holding the group of a request.
+type groupKey struct{}
+
+// group returns the live group tagged tag, creating it if needed, and takes a
+// reference on it.
+func (client *AirstackClient) group(tag string) *requestGroup {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if client.groups == nil {
+		client.groups = make(map[string]*requestGroup)
+	}
+	g, ok := client.groups[tag]
+	if !ok {
+		g = &requestGroup{tag: tag}
+		g.ctx, g.cancel = context.WithCancel(context.Background())
+		client.groups[tag] = g
+	}
+	g.refs++
+	return g
+}
+
+// releaseGroup drops a reference on g, removing the group once the last of
+// its contexts is released.
+func (client *AirstackClient) releaseGroup(g *requestGroup) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	g.refs--
+	if g.refs > 0 {
+		return
+	}
+	if client.groups[g.tag] == g {
+		delete(client.groups, g.tag)
+	}
+	g.cancel()
+}
+
+// WithGroup returns a context whose requests belong to the group tagged tag,
+// e.g. all the queries of one census build. The context is canceled when
+// CancelGroup is called for tag or when the returned cancel function is
+// called, which releases its resources and must be called once done. The
+// group is dropped once every context created for it is released.
+func (client *AirstackClient) WithGroup(ctx context.Context, tag string) (context.Context, context.CancelFunc) {
+	g := client.group(tag)
+	ctx, cancel := context.WithCancel(context.WithValue(ctx, groupKey{}, g))
+	stop := context.AfterFunc(g.ctx, cancel)
+	var once sync.Once
+	return ctx, func() {
+		once.Do(func() {
+			stop()
+			cancel()
+			client.releaseGroup(g)
+		})
+	}
+}
+
+// CancelGroup cancels every request of the group tagged tag, including
+// in-flight ones, and returns how many requests were in flight. Contexts
+// created afterwards by WithGroup for the same tag start a new group.
+func (client *AirstackClient) CancelGroup(tag string) int {
+	client.mu.Lock()
+	g, ok := client.groups[tag]
+	delete(client.groups, tag)
+	client.mu.Unlock()
+	if !ok {
+		return 0
+	}
+	g.mu.Lock()
+	inFlight := g.inFlight
+	g.mu.Unlock()
+	g.cancel()
+	return inFlight
+}
+
+// InFlight returns the number of requests of the group tagged tag that are
+// currently being executed.
+func (client *AirstackClient) InFlight(tag string) int {
+	client.mu.Lock()
+	g, ok := client.groups[tag]
+	client.mu.Unlock()
+	if !ok {
+		return 0
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.inFlight
+}
+
+// trackGroup counts a request of the group of ctx as in flight and returns the
+// function marking it as done.
+func trackGroup(ctx context.Context) func() {
+	g, ok := ctx.Value(groupKey{}).(*requestGroup)
+	if !ok {
+		return func() {}
+	}
+	g.mu.Lock()
+	g.inFlight++
+	g.mu.Unlock()
+	return func() {
+		g.mu.Lock()
+		g.inFlight--
+		g.mu.Unlock()
+	}
+}
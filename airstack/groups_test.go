@@ -0,0 +1,81 @@
+package airstack_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/vocdoni/go-airstack/airstack"
+	"github.com/vocdoni/go-airstack/airstacktest"
+)
+
+func TestCancelGroupAbortsInFlightRequests(t *testing.T) {
+	s := airstacktest.NewServer()
+	defer s.Close()
+	entered, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+	s.HandleFunc("GetWallet", func(airstacktest.Request) airstacktest.Response {
+		entered <- struct{}{}
+		<-release
+		return airstacktest.Response{StatusCode: http.StatusOK, Body: []byte(`{"data":{"Wallet":null}}`)}
+	})
+	client := s.Client()
+	client.ReproDir = t.TempDir()
+	alerted := false
+	client.TrackErrorBudget(airstack.ErrorBudget{Threshold: 0.1, MinRequests: 1, OnAlert: func(airstack.Alert) { alerted = true }})
+
+	ctx, cancel := client.WithGroup(context.Background(), "census")
+	defer cancel()
+	errc := make(chan error, 1)
+	go func() {
+		_, err := client.GetWallet(ctx, "alice.eth")
+		errc <- err
+	}()
+	<-entered
+	if n := client.CancelGroup("census"); n != 1 {
+		t.Fatalf("CancelGroup returned %d in-flight requests, want 1", n)
+	}
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want context.Canceled", err)
+	}
+
+	if usage := client.Usage()[""]; usage.Requests != 1 || usage.Errors != 0 {
+		t.Fatalf("got usage %+v, want the canceled request not counted as an error", usage)
+	}
+	if alerted {
+		t.Fatal("the canceled request raised an error budget alert")
+	}
+	if files, _ := os.ReadDir(client.ReproDir); len(files) != 0 {
+		t.Fatalf("got %d reproductions of the canceled request", len(files))
+	}
+}
+
+func TestWithGroupReleasesGroups(t *testing.T) {
+	client := airstack.NewAirstackClient("test")
+	ctx, cancel := client.WithGroup(context.Background(), "census")
+	_, cancelOther := client.WithGroup(context.Background(), "census")
+
+	cancel()
+	cancel()
+	if ctx.Err() == nil {
+		t.Fatal("the released context is not canceled")
+	}
+	if n := client.CancelGroup("census"); n != 0 {
+		t.Fatalf("CancelGroup returned %d, want 0", n)
+	}
+	cancelOther()
+
+	// Releasing every context drops the group, so the tag starts afresh.
+	ctx, cancel = client.WithGroup(context.Background(), "build")
+	cancel()
+	if n := airstack.Groups(client); n != 0 {
+		t.Fatalf("got %d live groups, want released groups dropped", n)
+	}
+	ctx, cancel = client.WithGroup(context.Background(), "build")
+	defer cancel()
+	if ctx.Err() != nil {
+		t.Fatalf("new context of a released group is done: %v", ctx.Err())
+	}
+}
@@ -0,0 +1,189 @@
+package airstack
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+)
+
+// defaultTopHoldersTTL is the default lifetime of a cached holder set.
+const defaultTopHoldersTTL = 5 * time.Minute
+
+// Token identifies a token contract on a given blockchain.
+type Token struct {
+	Address    string
+	Blockchain string
+}
+
+func (t Token) key() string {
+	return strings.ToLower(t.Blockchain) + ":" + strings.ToLower(t.Address)
+}
+
+// TokenHolder represents the aggregated balance of a single holder of a token.
+type TokenHolder struct {
+	Address string
+	Amount  *big.Int
+}
+
+// tokenHolderBalance is a TokenBalance row as returned by the holders query.
+type tokenHolderBalance struct {
+	Owner struct {
+		Identity  string   `json:"identity"`
+		Addresses []string `json:"addresses"`
+	} `json:"owner"`
+	Amount  string `json:"amount"`
+	TokenId string `json:"tokenId"`
+}
+
+// address returns the address identifying the owner of the balance.
+func (b tokenHolderBalance) address() string {
+	if len(b.Owner.Addresses) > 0 {
+		return strings.ToLower(b.Owner.Addresses[0])
+	}
+	return strings.ToLower(b.Owner.Identity)
+}
+
+const tokenHoldersQuery = `
+query GetTokenHolders($tokenAddress: Address!, $blockchain: TokenBlockchain!, $limit: Int, $cursor: String) {
+	TokenBalances(
+		input: {filter: {tokenAddress: {_eq: $tokenAddress}}, blockchain: $blockchain, limit: $limit, cursor: $cursor}
+	) {
+		TokenBalance {
+			owner {
+				identity
+				addresses
+			}
+			amount
+			tokenId
+		}
+		pageInfo {
+			nextCursor
+			prevCursor
+		}
+	}
+}
+`
+
+// holdersQuery returns the paginated query listing every balance of token, run
+// on behalf of helper.
+func holdersQuery(helper string, token Token) pagedQuery {
+	return pagedQuery{
+		helper:     helper,
+		blockchain: token.Blockchain,
+		query:      tokenHoldersQuery,
+		root:       QueryTokenBalances,
+		field:      "TokenBalance",
+		variables: map[string]interface{}{
+			"tokenAddress": token.Address,
+			"blockchain":   token.Blockchain,
+		},
+	}
+}
+
+// GetTokenHolders fetches every holder of token, aggregating the balances of
+// owners holding several token ids, sorted by descending amount.
+func (client *AirstackClient) GetTokenHolders(ctx context.Context, token Token) ([]TokenHolder, error) {
+	return client.getTokenHolders(ctx, "GetTokenHolders", token)
+}
+
+// getTokenHolders implements GetTokenHolders on behalf of helper.
+func (client *AirstackClient) getTokenHolders(ctx context.Context, helper string, token Token) ([]TokenHolder, error) {
+	rows, err := fetchAll[tokenHolderBalance](ctx, client, holdersQuery(helper, token))
+	if err != nil {
+		return nil, err
+	}
+	return aggregateHolders(rows), nil
+}
+
+// aggregateHolders sums the balance rows per owner and sorts the result by
+// descending amount, breaking ties by address. Rows repeated across pages,
+// with the same owner and token id, are counted once.
+func aggregateHolders(rows []tokenHolderBalance) []TokenHolder {
+	totals := make(map[string]*big.Int)
+	seen := make(map[[2]string]bool, len(rows))
+	for _, row := range rows {
+		amount, ok := new(big.Int).SetString(row.Amount, 10)
+		if !ok {
+			continue
+		}
+		addr := row.address()
+		key := [2]string{addr, row.TokenId}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if total, ok := totals[addr]; ok {
+			total.Add(total, amount)
+		} else {
+			totals[addr] = amount
+		}
+	}
+	holders := make([]TokenHolder, 0, len(totals))
+	for addr, amount := range totals {
+		holders = append(holders, TokenHolder{Address: addr, Amount: amount})
+	}
+	sort.Slice(holders, func(i, j int) bool {
+		if c := holders[i].Amount.Cmp(holders[j].Amount); c != 0 {
+			return c > 0
+		}
+		return holders[i].Address < holders[j].Address
+	})
+	return holders
+}
+
+// holdersCache returns the client's holder cache, creating it on first use.
+func (client *AirstackClient) holdersCache() *ttlCache[[]TokenHolder] {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if client.holders == nil {
+		client.holders = newTTLCache[[]TokenHolder]()
+	}
+	return client.holders
+}
+
+// GetTopHolders returns the n largest holders of token. Holder sets are cached
+// for TopHoldersTTL; once an entry expires the stale set is still served while
+// a single background refresh fetches a new one, so leaderboards polling this
+// method issue at most one holder scan per token and TTL.
+func (client *AirstackClient) GetTopHolders(ctx context.Context, token Token, n int) ([]TokenHolder, error) {
+	cache := client.holdersCache()
+	key := token.key()
+
+	holders, age, ok := cache.get(key)
+	if !ok {
+		var err error
+		holders, err = client.getTokenHolders(ctx, "GetTopHolders", token)
+		if err != nil {
+			return nil, err
+		}
+		cache.set(key, holders)
+	} else if age >= client.TopHoldersTTL && cache.startRefresh(key) {
+		go func() {
+			defer cache.endRefresh(key)
+			if fresh, err := client.getTokenHolders(context.WithoutCancel(ctx), "GetTopHolders", token); err == nil {
+				cache.set(key, fresh)
+			}
+		}()
+	}
+	return topN(holders, n), nil
+}
+
+// InvalidateTopHolders drops the cached holder set of token.
+func (client *AirstackClient) InvalidateTopHolders(token Token) {
+	client.holdersCache().delete(token.key())
+}
+
+// topN returns a copy of the first n holders, so callers cannot modify the
+// cached amounts.
+func topN(holders []TokenHolder, n int) []TokenHolder {
+	if n > len(holders) || n < 0 {
+		n = len(holders)
+	}
+	out := make([]TokenHolder, n)
+	for i, h := range holders[:n] {
+		out[i] = TokenHolder{Address: h.Address, Amount: new(big.Int).Set(h.Amount)}
+	}
+	return out
+}
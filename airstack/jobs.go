@@ -0,0 +1,222 @@
+package airstack
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sort"
+	"sync"
+	"time"
+)
+
+// JobStatus is the state of a Job.
+type JobStatus string
+
+// Job statuses.
+const (
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed"
+	JobCanceled  JobStatus = "canceled"
+)
+
+// JobFunc is the work run by a job. It reports progress through job and
+// returns the job result.
+type JobFunc func(ctx context.Context, job *Job) (interface{}, error)
+
+// Job is a long-running operation started with StartJob, such as a census
+// build, a bulk resolution or an export, that can be polled and canceled.
+type Job struct {
+	ID        string
+	Kind      string
+	CreatedAt time.Time
+
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu         sync.Mutex
+	status     JobStatus
+	completed  int
+	total      int
+	result     interface{}
+	err        error
+	finishedAt time.Time
+}
+
+// Status returns the current status of the job.
+func (j *Job) Status() JobStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.status
+}
+
+// SetProgress records that completed of total units of work are done. A total
+// of zero means the amount of work is unknown.
+func (j *Job) SetProgress(completed, total int) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.completed, j.total = completed, total
+}
+
+// Progress returns the progress last reported by the job.
+func (j *Job) Progress() (completed, total int) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.completed, j.total
+}
+
+// Result returns the result and error of a finished job. Both are nil while
+// the job is running.
+func (j *Job) Result() (interface{}, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.result, j.err
+}
+
+// FinishedAt returns when the job finished, zero while it is running.
+func (j *Job) FinishedAt() time.Time {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.finishedAt
+}
+
+// Done returns a channel closed when the job finishes.
+func (j *Job) Done() <-chan struct{} {
+	return j.done
+}
+
+// Cancel stops the job. Its status becomes JobCanceled once it returns.
+func (j *Job) Cancel() {
+	j.cancel()
+}
+
+// Wait blocks until the job finishes or ctx is done and returns its result.
+func (j *Job) Wait(ctx context.Context) (interface{}, error) {
+	select {
+	case <-j.done:
+		return j.Result()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// newJobID returns a random job identifier.
+func newJobID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// StartJob runs fn in the background and returns its Job, which the client
+// keeps until RemoveJob is called. The job context carries the values of ctx,
+// such as its label, but is not canceled with it.
+func (client *AirstackClient) StartJob(ctx context.Context, kind string, fn JobFunc) *Job {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	job := &Job{
+		ID:        newJobID(),
+		Kind:      kind,
+		CreatedAt: time.Now(),
+		cancel:    cancel,
+		done:      make(chan struct{}),
+		status:    JobRunning,
+	}
+
+	client.mu.Lock()
+	if client.jobs == nil {
+		client.jobs = make(map[string]*Job)
+	}
+	client.jobs[job.ID] = job
+	client.mu.Unlock()
+
+	go func() {
+		defer close(job.done)
+		defer cancel()
+		result, err := fn(ctx, job)
+
+		job.mu.Lock()
+		defer job.mu.Unlock()
+		if err == nil && ctx.Err() != nil {
+			// Results gathered after a cancellation may be partial.
+			err = ctx.Err()
+		}
+		job.result, job.err = result, err
+		job.finishedAt = time.Now()
+		switch {
+		case ctx.Err() != nil:
+			job.status = JobCanceled
+		case err == nil:
+			job.status = JobSucceeded
+		default:
+			job.status = JobFailed
+		}
+	}()
+	return job
+}
+
+// Job returns the job with the given id.
+func (client *AirstackClient) Job(id string) (*Job, bool) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	job, ok := client.jobs[id]
+	return job, ok
+}
+
+// Jobs returns every job kept by the client, oldest first.
+func (client *AirstackClient) Jobs() []*Job {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	jobs := make([]*Job, 0, len(client.jobs))
+	for _, job := range client.jobs {
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].CreatedAt.Before(jobs[j].CreatedAt) })
+	return jobs
+}
+
+// RemoveJob cancels the job with the given id, if still running, and forgets
+// it.
+func (client *AirstackClient) RemoveJob(id string) {
+	client.mu.Lock()
+	job, ok := client.jobs[id]
+	delete(client.jobs, id)
+	client.mu.Unlock()
+	if ok {
+		job.Cancel()
+	}
+}
+
+// StartCensusJob builds the members of source in the background. The job
+// result is a []CensusMember.
+func (client *AirstackClient) StartCensusJob(ctx context.Context, source CensusSource) *Job {
+	return client.StartJob(ctx, "census", func(ctx context.Context, _ *Job) (interface{}, error) {
+		return source.Members(ctx)
+	})
+}
+
+// StartENSResolutionJob resolves names in the background, reporting progress
+// per chunk. The job result is a []ENSResult.
+func (client *AirstackClient) StartENSResolutionJob(ctx context.Context, names []string) *Job {
+	return client.StartJob(ctx, "ens-resolution", func(ctx context.Context, job *Job) (interface{}, error) {
+		results := make([]ENSResult, 0, len(names))
+		for _, chunk := range chunks(names, ensBatchSize) {
+			res, err := client.ResolveENSBatch(ctx, chunk)
+			results = append(results, res...)
+			if err != nil {
+				return results, err
+			}
+			job.SetProgress(len(results), len(names))
+		}
+		return results, nil
+	})
+}
+
+// StartHoldersExportJob runs ExportTokenHoldersCSV in the background. The job
+// result is the path of the exported file.
+func (client *AirstackClient) StartHoldersExportJob(ctx context.Context, token Token, path string, store Store) *Job {
+	return client.StartJob(ctx, "holders-export", func(ctx context.Context, _ *Job) (interface{}, error) {
+		if err := client.ExportTokenHoldersCSV(ctx, token, path, store); err != nil {
+			return nil, err
+		}
+		return path, nil
+	})
+}
@@ -0,0 +1,53 @@
+package airstack_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/vocdoni/go-airstack/airstack"
+	"github.com/vocdoni/go-airstack/airstacktest"
+)
+
+func TestCanceledJobStatus(t *testing.T) {
+	client := airstack.NewAirstackClient("test")
+	job := client.StartJob(context.Background(), "test", func(ctx context.Context, _ *airstack.Job) (interface{}, error) {
+		<-ctx.Done()
+		// A job returning its partial result without an error.
+		return "partial", nil
+	})
+	job.Cancel()
+	<-job.Done()
+	if status := job.Status(); status != airstack.JobCanceled {
+		t.Fatalf("got status %v, want JobCanceled", status)
+	}
+	if _, err := job.Result(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want context.Canceled", err)
+	}
+}
+
+func TestCanceledCensusJob(t *testing.T) {
+	s := airstacktest.NewServer()
+	defer s.Close()
+	entered, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+	s.HandleFunc("ResolveFarcasterIds", func(airstacktest.Request) airstacktest.Response {
+		entered <- struct{}{}
+		<-release
+		return airstacktest.Response{StatusCode: http.StatusOK, Body: []byte(`{"data":{}}`)}
+	})
+	client := s.Client()
+
+	source := airstack.NewMixedCensusSource(client, "0x00000000000000000000000000000000000000aa", "fc_fid:1")
+	job := client.StartCensusJob(context.Background(), source)
+	<-entered
+	job.Cancel()
+	<-job.Done()
+	if status := job.Status(); status != airstack.JobCanceled {
+		t.Fatalf("got status %v, want JobCanceled", status)
+	}
+	if result, err := job.Result(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got result %v and error %v, want context.Canceled", result, err)
+	}
+}
@@ -0,0 +1,81 @@
+package airstack
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrLimitExceeded is matched, through errors.Is, by every LimitExceededError.
+var ErrLimitExceeded = errors.New("airstack: limit exceeded")
+
+// Limits guards a paginated helper against unexpectedly large collections.
+// Zero values disable the corresponding guard.
+type Limits struct {
+	MaxPages int
+	MaxItems int
+	MaxBytes int64
+}
+
+// LimitExceededError is returned when a helper exceeds one of its Limits.
+type LimitExceededError struct {
+	Helper string
+	// Limit is the exceeded guard: "pages", "items" or "bytes".
+	Limit string
+	Max   int64
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("airstack: %s exceeded its limit of %d %s", e.Helper, e.Max, e.Limit)
+}
+
+// Is makes errors.Is(err, ErrLimitExceeded) match.
+func (e *LimitExceededError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
+
+// SetLimits sets the guards applied to every paginated call of helper, named
+// after the method called, e.g. "GetTokenHolders" or "GetTopHolders". Limits
+// of a method apply to the paginations it runs internally, and not those of
+// the method it is built on, so "GetTransferVolume" is guarded by its own
+// limits rather than those of "GetTokenTransfers".
+func (client *AirstackClient) SetLimits(helper string, limits Limits) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if client.limits == nil {
+		client.limits = make(map[string]Limits)
+	}
+	client.limits[helper] = limits
+}
+
+// helperLimits returns the guards of helper.
+func (client *AirstackClient) helperLimits(helper string) Limits {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	return client.limits[helper]
+}
+
+// limitTracker accumulates the pages, items and bytes of a paginated call and
+// checks them against its limits.
+type limitTracker struct {
+	helper string
+	limits Limits
+	pages  int
+	items  int
+	bytes  int64
+}
+
+// add accounts a page of items and size bytes.
+func (t *limitTracker) add(items int, size int) error {
+	t.pages++
+	t.items += items
+	t.bytes += int64(size)
+	switch {
+	case t.limits.MaxPages > 0 && t.pages > t.limits.MaxPages:
+		return &LimitExceededError{Helper: t.helper, Limit: "pages", Max: int64(t.limits.MaxPages)}
+	case t.limits.MaxItems > 0 && t.items > t.limits.MaxItems:
+		return &LimitExceededError{Helper: t.helper, Limit: "items", Max: int64(t.limits.MaxItems)}
+	case t.limits.MaxBytes > 0 && t.bytes > t.limits.MaxBytes:
+		return &LimitExceededError{Helper: t.helper, Limit: "bytes", Max: t.limits.MaxBytes}
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package airstack_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/vocdoni/go-airstack/airstack"
+	"github.com/vocdoni/go-airstack/airstacktest"
+)
+
+func TestLimitsApplyToCalledMethod(t *testing.T) {
+	_, client := serveHolders(t, &airstacktest.PaginationFixture{Items: airstacktest.TokenBalanceItems(450)})
+	client.SetLimits("GetTopHolders", airstack.Limits{MaxPages: 1})
+
+	_, err := client.GetTopHolders(context.Background(), testToken, 10)
+	var limitErr *airstack.LimitExceededError
+	if !errors.As(err, &limitErr) || limitErr.Helper != "GetTopHolders" || limitErr.Limit != "pages" {
+		t.Fatalf("got error %v, want the GetTopHolders page limit", err)
+	}
+	if _, err := client.GetTokenHolders(context.Background(), testToken); err != nil {
+		t.Fatalf("GetTokenHolders: %v, want the GetTopHolders limits not to apply", err)
+	}
+}
@@ -0,0 +1,87 @@
+package airstack
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+// ErrUnknownTenant is returned by ClientManager for tenants never registered.
+var ErrUnknownTenant = errors.New("airstack: unknown tenant")
+
+// TenantConfig configures the client of a single tenant.
+type TenantConfig struct {
+	APIKey string
+	// URL overrides the Airstack endpoint when set.
+	URL string
+	// RequestsPerSecond and Burst configure the tenant rate limiter. No
+	// limit is applied when RequestsPerSecond is zero.
+	RequestsPerSecond float64
+	Burst             int
+	// Label is the default metrics label of the tenant requests. It
+	// defaults to the tenant name.
+	Label string
+}
+
+// ClientManager holds one AirstackClient per tenant. Every client has its own
+// API key, rate limiter, caches and metrics, so tenants served from the same
+// process never share quota or cached data.
+type ClientManager struct {
+	mu      sync.Mutex
+	clients map[string]*AirstackClient
+}
+
+// NewClientManager returns an empty ClientManager.
+func NewClientManager() *ClientManager {
+	return &ClientManager{clients: make(map[string]*AirstackClient)}
+}
+
+// Register creates the client of tenant from cfg, replacing any previous one.
+func (m *ClientManager) Register(tenant string, cfg TenantConfig) *AirstackClient {
+	client := NewAirstackClient(cfg.APIKey)
+	if cfg.URL != "" {
+		client.URL = cfg.URL
+	}
+	if cfg.RequestsPerSecond > 0 {
+		client.Limiter = NewRateLimiter(cfg.RequestsPerSecond, cfg.Burst)
+	}
+	client.DefaultLabel = cfg.Label
+	if client.DefaultLabel == "" {
+		client.DefaultLabel = tenant
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clients[tenant] = client
+	return client
+}
+
+// Client returns the client of tenant.
+func (m *ClientManager) Client(tenant string) (*AirstackClient, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	client, ok := m.clients[tenant]
+	if !ok {
+		return nil, ErrUnknownTenant
+	}
+	return client, nil
+}
+
+// Remove drops the client of tenant.
+func (m *ClientManager) Remove(tenant string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.clients, tenant)
+}
+
+// Tenants returns the registered tenants, sorted.
+func (m *ClientManager) Tenants() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	tenants := make([]string, 0, len(m.clients))
+	for tenant := range m.clients {
+		tenants = append(tenants, tenant)
+	}
+	sort.Strings(tenants)
+	return tenants
+}
@@ -0,0 +1,139 @@
+package airstack
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// labelKey is the context key holding the caller-supplied operation label.
+type labelKey struct{}
+
+// WithLabel returns a context whose requests are attributed to label in the
+// client metrics and audit log, e.g. "census-build" or "profile-page".
+func WithLabel(ctx context.Context, label string) context.Context {
+	return context.WithValue(ctx, labelKey{}, label)
+}
+
+// LabelFromContext returns the label set with WithLabel, if any.
+func LabelFromContext(ctx context.Context) string {
+	label, _ := ctx.Value(labelKey{}).(string)
+	return label
+}
+
+// label returns the label of ctx, falling back to the client default label.
+func (client *AirstackClient) label(ctx context.Context) string {
+	if label := LabelFromContext(ctx); label != "" {
+		return label
+	}
+	return client.DefaultLabel
+}
+
+// RequestInfo describes a single request sent to Airstack.
+type RequestInfo struct {
+	Time       time.Time
+	Operation  string
+	Label      string
+	StatusCode int
+	Duration   time.Duration
+	Bytes      int
+	// Credits is the cost reported by Airstack, zero when not reported.
+	Credits float64
+	// Sent is false when the request failed before being sent, for
+	// instance while waiting for the rate limiter.
+	Sent bool
+	// Canceled is true when Err is the cancellation of the request context,
+	// which is not counted as a failure.
+	Canceled bool
+	Err      error
+}
+
+// LabelUsage aggregates the requests attributed to a label. Credits use the
+// cost reported by Airstack when available and a flat estimate otherwise.
+type LabelUsage struct {
+	Requests         int
+	Errors           int
+	Bytes            int64
+	Duration         time.Duration
+	EstimatedCredits float64
+}
+
+// metrics accumulates per-label usage and dispatches request observers.
+type metrics struct {
+	mu          sync.Mutex
+	usage       map[string]LabelUsage
+	observers   []func(RequestInfo)
+	accountants []CreditAccountant
+}
+
+// metricsState returns the client's metrics, creating them on first use.
+func (client *AirstackClient) metricsState() *metrics {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if client.metrics == nil {
+		client.metrics = &metrics{usage: make(map[string]LabelUsage)}
+	}
+	return client.metrics
+}
+
+// Observe registers fn to be called after every request sent by the client.
+func (client *AirstackClient) Observe(fn func(RequestInfo)) {
+	m := client.metricsState()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.observers = append(m.observers, fn)
+}
+
+// Usage returns a snapshot of the usage attributed to each label. Requests
+// without a label are reported under the empty label.
+func (client *AirstackClient) Usage() map[string]LabelUsage {
+	m := client.metricsState()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make(map[string]LabelUsage, len(m.usage))
+	for label, u := range m.usage {
+		out[label] = u
+	}
+	return out
+}
+
+// record accounts info in the label usage, the audit log, the credit
+// accountants and the observers.
+func (client *AirstackClient) record(ctx context.Context, info RequestInfo) {
+	m := client.metricsState()
+	m.mu.Lock()
+	u := m.usage[info.Label]
+	u.Requests++
+	if info.Err != nil && !info.Canceled {
+		u.Errors++
+	}
+	u.Bytes += int64(info.Bytes)
+	u.Duration += info.Duration
+	usage := creditUsage(info)
+	var accountants []CreditAccountant
+	if info.Sent {
+		// Only requests that reached Airstack cost credits.
+		u.EstimatedCredits += usage.Credits
+		accountants = append(accountants, m.accountants...)
+	}
+	m.usage[info.Label] = u
+	observers := append([]func(RequestInfo){}, m.observers...)
+	m.mu.Unlock()
+
+	client.audit(info)
+	client.account(ctx, accountants, usage)
+	for _, fn := range observers {
+		fn(info)
+	}
+}
+
+var operationNameRe = regexp.MustCompile(`^\s*(?:query|mutation)\s+(\w+)`)
+
+// OperationName extracts the operation name of a GraphQL document.
+func OperationName(query string) string {
+	if m := operationNameRe.FindStringSubmatch(query); m != nil {
+		return m[1]
+	}
+	return "anonymous"
+}
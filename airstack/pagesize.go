@@ -0,0 +1,106 @@
+package airstack
+
+import (
+	"sync"
+	"time"
+)
+
+// Page size bounds and adaptive tuning thresholds.
+const (
+	minPageSize             = 10
+	maxPageSize             = 200
+	defaultLatencyTarget    = 3 * time.Second
+	defaultMaxResponseBytes = 4 << 20
+)
+
+// QueryType names a paginated Airstack root.
+type QueryType string
+
+// Paginated roots used by the helpers of this package.
+const (
+	QueryTokenBalances   QueryType = "TokenBalances"
+	QueryTokenTransfers  QueryType = "TokenTransfers"
+	QuerySocials         QueryType = "Socials"
+	QueryPoaps           QueryType = "Poaps"
+	QueryPoapEvents      QueryType = "PoapEvents"
+	QuerySnapshots       QueryType = "Snapshots"
+	QueryDomains         QueryType = "Domains"
+	QuerySocialFollowers QueryType = "SocialFollowers"
+)
+
+// pageSizer keeps the configured and, in adaptive mode, the tuned page size
+// of every query type.
+type pageSizer struct {
+	mu         sync.Mutex
+	configured map[QueryType]int
+	tuned      map[QueryType]int
+}
+
+// pager returns the client's page sizer, creating it on first use.
+func (client *AirstackClient) pager() *pageSizer {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if client.pageSizes == nil {
+		client.pageSizes = &pageSizer{
+			configured: make(map[QueryType]int),
+			tuned:      make(map[QueryType]int),
+		}
+	}
+	return client.pageSizes
+}
+
+// clampPageSize bounds n to the page sizes Airstack accepts.
+func clampPageSize(n int) int {
+	return max(minPageSize, min(maxPageSize, n))
+}
+
+// SetPageSize sets the default page size used when paginating qt. Sizes are
+// clamped to the 10-200 range accepted by Airstack. In adaptive mode the size
+// is the starting point of the tuning.
+func (client *AirstackClient) SetPageSize(qt QueryType, n int) {
+	p := client.pager()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.configured[qt] = clampPageSize(n)
+	delete(p.tuned, qt)
+}
+
+// PageSize returns the page size the next page of qt will be requested with.
+func (client *AirstackClient) PageSize(qt QueryType) int {
+	p := client.pager()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if client.AdaptivePaging {
+		if n, ok := p.tuned[qt]; ok {
+			return n
+		}
+	}
+	if n, ok := p.configured[qt]; ok {
+		return n
+	}
+	return defaultPageSize
+}
+
+// observePage feeds the outcome of a page request into the adaptive tuner.
+// Slow, oversized or failed pages halve the page size, while pages well under
+// the latency target grow it by a quarter.
+func (client *AirstackClient) observePage(qt QueryType, size int, latency time.Duration, bytes int, failed bool) {
+	if !client.AdaptivePaging {
+		return
+	}
+	target := client.AdaptiveLatencyTarget
+	if target <= 0 {
+		target = defaultLatencyTarget
+	}
+	next := size
+	switch {
+	case failed, latency > target, bytes > defaultMaxResponseBytes:
+		next = size / 2
+	case latency < target/2:
+		next = size + max(size/4, 1)
+	}
+	p := client.pager()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.tuned[qt] = clampPageSize(next)
+}
@@ -0,0 +1,193 @@
+package airstack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// defaultPageSize is the page size used for query types without a configured
+// one.
+const defaultPageSize = maxPageSize
+
+// ResponseError is returned by helpers when Airstack answers with an HTTP
+// error or a GraphQL "errors" field.
+type ResponseError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("airstack: %s (status %d)", e.Message, e.StatusCode)
+}
+
+// responseError converts the error carried by a QueryResponse into a Go error.
+func responseError(resp *QueryResponse) error {
+	if resp.Error == "" {
+		return nil
+	}
+	return &ResponseError{StatusCode: resp.StatusCode, Message: resp.Error}
+}
+
+// query executes a GraphQL query and decodes its data into out.
+func (client *AirstackClient) query(ctx context.Context, query string, variables map[string]interface{}, out interface{}) error {
+	_, err := client.ExecuteQueryInto(ctx, query, variables, out)
+	return err
+}
+
+// pageInfo holds the cursors Airstack returns for paginated roots.
+type pageInfo struct {
+	NextCursor string `json:"nextCursor"`
+	PrevCursor string `json:"prevCursor"`
+}
+
+// pagedQuery describes a paginated GraphQL root. The query must accept the
+// $limit and $cursor variables and select pageInfo on root. helper names the
+// method running the query, whose Limits apply to it, and blockchain, when
+// set, is checked against the client Capabilities.
+type pagedQuery struct {
+	helper     string
+	blockchain string
+	query      string
+	root       QueryType
+	field      string
+	variables  map[string]interface{}
+}
+
+// fetchPage fetches the page of q starting at cursor and returns its raw items
+// together with the cursor of the following page.
+func (client *AirstackClient) fetchPage(ctx context.Context, q pagedQuery, cursor string) (json.RawMessage, string, error) {
+	variables := make(map[string]interface{}, len(q.variables)+2)
+	for k, v := range q.variables {
+		variables[k] = v
+	}
+	size := client.PageSize(q.root)
+	variables["limit"] = size
+	variables["cursor"] = cursor
+
+	start := time.Now()
+	resp, err := client.ExecuteQuery(ctx, q.query, variables)
+	if err == nil {
+		err = responseError(resp)
+	}
+	if err != nil {
+		client.observePage(q.root, size, time.Since(start), 0, true)
+		return nil, "", err
+	}
+	client.observePage(q.root, size, time.Since(start), len(resp.Data), false)
+
+	var data map[QueryType]map[string]json.RawMessage
+	if len(resp.Data) > 0 {
+		if err := json.Unmarshal(resp.Data, &data); err != nil {
+			return nil, "", err
+		}
+	}
+	root := data[q.root]
+	if root == nil {
+		// Airstack returns a null root when nothing matches the filter.
+		return nil, "", nil
+	}
+	var info pageInfo
+	if raw, ok := root["pageInfo"]; ok && len(raw) > 0 {
+		if err := json.Unmarshal(raw, &info); err != nil {
+			return nil, "", err
+		}
+	}
+	return root[q.field], info.NextCursor, nil
+}
+
+// paginate walks every page of q starting at cursor, calling fn with the raw
+// items of each page and the cursor of the page that follows it. It stops
+// with a LimitExceededError as soon as the Limits of q.helper are exceeded.
+func (client *AirstackClient) paginate(ctx context.Context, q pagedQuery, cursor string, fn func(items json.RawMessage, next string) error) error {
+	if q.blockchain != "" {
+		if err := client.checkChain(q.blockchain, q.root); err != nil {
+			return err
+		}
+	}
+	tracker := &limitTracker{helper: q.helper, limits: client.helperLimits(q.helper)}
+	for {
+		items, next, err := client.fetchPageWithRetry(ctx, q, cursor)
+		if err != nil {
+			return err
+		}
+		count := 0
+		if tracker.limits.MaxItems > 0 && len(items) > 0 {
+			var elems []json.RawMessage
+			if err := json.Unmarshal(items, &elems); err != nil {
+				return err
+			}
+			count = len(elems)
+		}
+		if err := tracker.add(count, len(items)); err != nil {
+			return err
+		}
+		if err := fn(items, next); err != nil {
+			return err
+		}
+		if next == "" {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+// fetchAll collects every item of q decoded as T.
+func fetchAll[T any](ctx context.Context, client *AirstackClient, q pagedQuery) ([]T, error) {
+	var all []T
+	err := client.paginate(ctx, q, "", func(items json.RawMessage, _ string) error {
+		if len(items) == 0 || string(items) == "null" {
+			return nil
+		}
+		var page []T
+		if err := json.Unmarshal(items, &page); err != nil {
+			return err
+		}
+		all = append(all, validate(client, q.helper, page)...)
+		return nil
+	})
+	return all, err
+}
+
+// Page retry defaults.
+const (
+	defaultPageRetries      = 3
+	defaultPageRetryBackoff = time.Second
+)
+
+// retriable reports whether a failed page fetch may succeed if sent again:
+// network failures, rate limiting and server errors.
+func retriable(err error) bool {
+	var respErr *ResponseError
+	if !errors.As(err, &respErr) {
+		return false
+	}
+	return respErr.StatusCode == 0 || respErr.StatusCode == http.StatusTooManyRequests || respErr.StatusCode >= 500
+}
+
+// fetchPageWithRetry fetches the page of q at cursor, retrying that page alone
+// up to PageRetries times with exponential backoff when the failure is
+// retriable, so a transient error does not abort a long pagination.
+func (client *AirstackClient) fetchPageWithRetry(ctx context.Context, q pagedQuery, cursor string) (json.RawMessage, string, error) {
+	backoff := client.PageRetryBackoff
+	if backoff <= 0 {
+		backoff = defaultPageRetryBackoff
+	}
+	for attempt := 0; ; attempt++ {
+		items, next, err := client.fetchPage(ctx, q, cursor)
+		if err == nil || attempt >= client.PageRetries || !retriable(err) {
+			return items, next, err
+		}
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, "", ctx.Err()
+		}
+		backoff *= 2
+	}
+}
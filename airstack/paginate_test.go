@@ -0,0 +1,143 @@
+package airstack_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vocdoni/go-airstack/airstack"
+	"github.com/vocdoni/go-airstack/airstacktest"
+)
+
+var testToken = airstack.Token{Address: "0x0000000000000000000000000000000000000001", Blockchain: "ethereum"}
+
+// serveHolders starts a fake server answering the holders query with f.
+func serveHolders(t *testing.T, f *airstacktest.PaginationFixture) (*airstacktest.Server, *airstack.AirstackClient) {
+	t.Helper()
+	f.Root, f.Field = "TokenBalances", "TokenBalance"
+	s := airstacktest.NewServer()
+	t.Cleanup(s.Close)
+	s.HandleFunc("GetTokenHolders", f.Handler())
+	client := s.Client()
+	client.PageRetryBackoff = time.Millisecond
+	return s, client
+}
+
+// checkHolders verifies holders are the n owners of TokenBalanceItems(n).
+func checkHolders(t *testing.T, holders []airstack.TokenHolder, n int) {
+	t.Helper()
+	if len(holders) != n {
+		t.Fatalf("got %d holders, want %d", len(holders), n)
+	}
+	sum := new(big.Int)
+	for _, h := range holders {
+		sum.Add(sum, h.Amount)
+	}
+	if want := big.NewInt(int64(n * (n + 1) / 2)); sum.Cmp(want) != 0 {
+		t.Fatalf("got summed balance %s, want %s", sum, want)
+	}
+}
+
+func TestPaginateEmptyLastPage(t *testing.T) {
+	s, client := serveHolders(t, &airstacktest.PaginationFixture{
+		Items:         airstacktest.TokenBalanceItems(450),
+		EmptyLastPage: true,
+	})
+	rows, err := airstack.FetchHolderRows(context.Background(), client, testToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 450 {
+		t.Fatalf("got %d rows, want 450", len(rows))
+	}
+	if n := len(s.Requests()); n != 4 {
+		t.Fatalf("got %d requests, want 3 pages and the empty one", n)
+	}
+}
+
+func TestPaginateDuplicates(t *testing.T) {
+	_, client := serveHolders(t, &airstacktest.PaginationFixture{
+		Items:      airstacktest.TokenBalanceItems(450),
+		Duplicates: 2,
+	})
+	rows, err := airstack.FetchHolderRows(context.Background(), client, testToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 454 {
+		t.Fatalf("got %d rows, want 450 and 2 repeated on each later page", len(rows))
+	}
+	holders, err := client.GetTokenHolders(context.Background(), testToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkHolders(t, holders, 450)
+}
+
+func TestPaginateExpiredCursor(t *testing.T) {
+	s, client := serveHolders(t, &airstacktest.PaginationFixture{
+		Items:             airstacktest.TokenBalanceItems(450),
+		ExpiredCursorPage: 1,
+	})
+	_, err := client.GetTokenHolders(context.Background(), testToken)
+	var respErr *airstack.ResponseError
+	if !errors.As(err, &respErr) || !strings.Contains(respErr.Message, "cursor expired") {
+		t.Fatalf("got error %v, want cursor expired", err)
+	}
+	if n := len(s.Requests()); n != 2 {
+		t.Fatalf("got %d requests, want the expired cursor not to be retried", n)
+	}
+}
+
+func TestPaginateRateLimitRetry(t *testing.T) {
+	s, client := serveHolders(t, &airstacktest.PaginationFixture{
+		Items:          airstacktest.TokenBalanceItems(450),
+		RateLimitPage:  1,
+		RateLimitTimes: 2,
+	})
+	holders, err := client.GetTokenHolders(context.Background(), testToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkHolders(t, holders, 450)
+	if n := len(s.Requests()); n != 5 {
+		t.Fatalf("got %d requests, want 3 pages and 2 rate limited retries", n)
+	}
+
+	_, client = serveHolders(t, &airstacktest.PaginationFixture{
+		Items:          airstacktest.TokenBalanceItems(450),
+		RateLimitPage:  1,
+		RateLimitTimes: 10,
+	})
+	_, err = client.GetTokenHolders(context.Background(), testToken)
+	var respErr *airstack.ResponseError
+	if !errors.As(err, &respErr) || respErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("got error %v, want status 429 once retries are exhausted", err)
+	}
+}
+
+func TestPaginateAdaptivePageSize(t *testing.T) {
+	s, client := serveHolders(t, &airstacktest.PaginationFixture{
+		Items:          airstacktest.TokenBalanceItems(1000),
+		RateLimitPage:  2,
+		RateLimitTimes: 1,
+	})
+	client.AdaptivePaging = true
+	client.AdaptiveLatencyTarget = time.Nanosecond
+	holders, err := client.GetTokenHolders(context.Background(), testToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkHolders(t, holders, 1000)
+	sizes := make(map[float64]bool)
+	for _, r := range s.Requests() {
+		sizes[r.Variables["limit"].(float64)] = true
+	}
+	if len(sizes) < 2 {
+		t.Fatalf("got page sizes %v, want the walk to change its page size", sizes)
+	}
+}
@@ -0,0 +1,159 @@
+package airstack
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// PoapEvent represents a POAP event.
+type PoapEvent struct {
+	EventId        string    `json:"eventId"`
+	EventName      string    `json:"eventName"`
+	StartDate      time.Time `json:"startDate"`
+	EndDate        time.Time `json:"endDate"`
+	Country        string    `json:"country"`
+	City           string    `json:"city"`
+	IsVirtualEvent bool      `json:"isVirtualEvent"`
+}
+
+// Poap represents a POAP held by a wallet.
+type Poap struct {
+	EventId   string    `json:"eventId"`
+	TokenId   string    `json:"tokenId"`
+	PoapEvent PoapEvent `json:"poapEvent"`
+}
+
+// PoapEventFilter selects POAP events. Since and Until bound the event start
+// date, Virtual selects virtual (true) or in-person (false) events, and empty
+// fields are not filtered on. GetPoaps matches Country and City
+// case-insensitively, while GetPoapEvents filters them server-side, where
+// they must match the spelling stored by Airstack exactly.
+type PoapEventFilter struct {
+	Since   time.Time
+	Until   time.Time
+	Country string
+	City    string
+	Virtual *bool
+}
+
+// graphQL returns the PoapEventFilter input matching f.
+func (f PoapEventFilter) graphQL() map[string]interface{} {
+	filter := make(map[string]interface{})
+	if f.Country != "" {
+		filter["country"] = map[string]interface{}{"_eq": f.Country}
+	}
+	if f.City != "" {
+		filter["city"] = map[string]interface{}{"_eq": f.City}
+	}
+	if f.Virtual != nil {
+		filter["isVirtualEvent"] = map[string]interface{}{"_eq": *f.Virtual}
+	}
+	date := make(map[string]interface{})
+	if !f.Since.IsZero() {
+		date["_gte"] = f.Since.UTC().Format(time.RFC3339)
+	}
+	if !f.Until.IsZero() {
+		date["_lte"] = f.Until.UTC().Format(time.RFC3339)
+	}
+	if len(date) > 0 {
+		filter["startDate"] = date
+	}
+	return filter
+}
+
+// matches reports whether event satisfies f.
+func (f PoapEventFilter) matches(event PoapEvent) bool {
+	switch {
+	case !f.Since.IsZero() && event.StartDate.Before(f.Since):
+		return false
+	case !f.Until.IsZero() && event.StartDate.After(f.Until):
+		return false
+	case f.Country != "" && !strings.EqualFold(f.Country, event.Country):
+		return false
+	case f.City != "" && !strings.EqualFold(f.City, event.City):
+		return false
+	case f.Virtual != nil && *f.Virtual != event.IsVirtualEvent:
+		return false
+	}
+	return true
+}
+
+const poapEventsQuery = `
+query GetPoapEvents($filter: PoapEventFilter!, $limit: Int, $cursor: String) {
+	PoapEvents(input: {filter: $filter, blockchain: ALL, limit: $limit, cursor: $cursor}) {
+		PoapEvent {
+			eventId
+			eventName
+			startDate
+			endDate
+			country
+			city
+			isVirtualEvent
+		}
+		pageInfo {
+			nextCursor
+			prevCursor
+		}
+	}
+}
+`
+
+// GetPoapEvents fetches every POAP event matching filter. The filter is
+// applied by Airstack, so Country and City are case-sensitive.
+func (client *AirstackClient) GetPoapEvents(ctx context.Context, filter PoapEventFilter) ([]PoapEvent, error) {
+	return fetchAll[PoapEvent](ctx, client, pagedQuery{
+		helper:    "GetPoapEvents",
+		query:     poapEventsQuery,
+		root:      QueryPoapEvents,
+		field:     "PoapEvent",
+		variables: map[string]interface{}{"filter": filter.graphQL()},
+	})
+}
+
+const poapsQuery = `
+query GetPoaps($owner: Identity!, $limit: Int, $cursor: String) {
+	Poaps(input: {filter: {owner: {_eq: $owner}}, blockchain: ALL, limit: $limit, cursor: $cursor}) {
+		Poap {
+			eventId
+			tokenId
+			poapEvent {
+				eventId
+				eventName
+				startDate
+				endDate
+				country
+				city
+				isVirtualEvent
+			}
+		}
+		pageInfo {
+			nextCursor
+			prevCursor
+		}
+	}
+}
+`
+
+// GetPoaps fetches the POAPs held by owner whose event matches filter. The
+// Poaps root cannot filter on event attributes, so the filter is applied to
+// the returned events.
+func (client *AirstackClient) GetPoaps(ctx context.Context, owner string, filter PoapEventFilter) ([]Poap, error) {
+	poaps, err := fetchAll[Poap](ctx, client, pagedQuery{
+		helper:    "GetPoaps",
+		query:     poapsQuery,
+		root:      QueryPoaps,
+		field:     "Poap",
+		variables: map[string]interface{}{"owner": owner},
+	})
+	if err != nil {
+		return nil, err
+	}
+	matching := poaps[:0]
+	for _, p := range poaps {
+		if filter.matches(p.PoapEvent) {
+			matching = append(matching, p)
+		}
+	}
+	return matching, nil
+}
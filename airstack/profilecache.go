@@ -0,0 +1,77 @@
+package airstack
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// defaultProfileCacheTTL is the default lifetime of cached profile lookups.
+const defaultProfileCacheTTL = 10 * time.Minute
+
+// ProfileCache is a read-through cache of wallet and socials lookups keyed by
+// identity. Entries older than the cache TTL are fetched again on access.
+type ProfileCache struct {
+	client  *AirstackClient
+	ttl     time.Duration
+	wallets *ttlCache[*Wallet]
+	socials *ttlCache[[]Social]
+}
+
+// NewProfileCache returns a ProfileCache resolving misses with client. A ttl of
+// zero uses the default of ten minutes.
+func NewProfileCache(client *AirstackClient, ttl time.Duration) *ProfileCache {
+	if ttl <= 0 {
+		ttl = defaultProfileCacheTTL
+	}
+	return &ProfileCache{
+		client:  client,
+		ttl:     ttl,
+		wallets: newTTLCache[*Wallet](),
+		socials: newTTLCache[[]Social](),
+	}
+}
+
+// profileKey normalizes identity so equivalent spellings share an entry.
+func profileKey(identity string) string {
+	return strings.ToLower(strings.TrimSpace(identity))
+}
+
+// Wallet returns the wallet of identity, from the cache when fresh. Partial
+// wallets returned in soft-fail mode are not cached, so callers that did not
+// opt into soft-fail never receive them.
+func (c *ProfileCache) Wallet(ctx context.Context, identity string) (*Wallet, error) {
+	key := profileKey(identity)
+	if wallet, age, ok := c.wallets.get(key); ok && age < c.ttl {
+		return wallet, nil
+	}
+	wallet, err := c.client.GetWallet(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+	if wallet == nil || len(wallet.Errors) == 0 {
+		c.wallets.set(key, wallet)
+	}
+	return wallet, nil
+}
+
+// Socials returns the social profiles of identity, from the cache when fresh.
+func (c *ProfileCache) Socials(ctx context.Context, identity string) ([]Social, error) {
+	key := profileKey(identity)
+	if socials, age, ok := c.socials.get(key); ok && age < c.ttl {
+		return socials, nil
+	}
+	socials, err := c.client.GetSocials(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+	c.socials.set(key, socials)
+	return socials, nil
+}
+
+// Invalidate drops every cached lookup of identity.
+func (c *ProfileCache) Invalidate(identity string) {
+	key := profileKey(identity)
+	c.wallets.delete(key)
+	c.socials.delete(key)
+}
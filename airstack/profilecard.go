@@ -0,0 +1,118 @@
+package airstack
+
+import "context"
+
+// profileCardTopTokens is the number of token balances shown on a card.
+const profileCardTopTokens = 10
+
+// SocialHandle is a social profile shown on a profile card.
+type SocialHandle struct {
+	DappName      string
+	ProfileName   string
+	FollowerCount int
+}
+
+// ProfileCard gathers what a UI needs to render a profile summary.
+type ProfileCard struct {
+	Identity    string
+	Addresses   []string
+	PrimaryName string
+	Avatar      string
+	Socials     []SocialHandle
+	// Followers is the sum of the follower counts of every social profile.
+	Followers int
+	TopTokens []TokenBalance
+	PoapCount int
+	// Errors lists the sections that failed in soft-fail mode.
+	Errors []SectionError
+}
+
+const profileCardQuery = `
+query GetProfileCard($identity: Identity!, $tokens: Int) {
+	Wallet(input: {identity: $identity, blockchain: ethereum}) {
+		identity
+		addresses
+		primaryDomain {
+			name
+		}
+		socials {
+			dappName
+			profileName
+			profileImage
+			followerCount
+		}
+	}
+	TokenBalances(
+		input: {filter: {owner: {_eq: $identity}}, blockchain: ethereum, limit: $tokens, order: {lastUpdatedTimestamp: DESC}}
+	) {
+		TokenBalance {
+			amount
+			blockchain
+			tokenAddress
+			tokenId
+		}
+	}
+	Poaps(input: {filter: {owner: {_eq: $identity}}, blockchain: ALL, limit: 200}) {
+		Poap {
+			eventId
+		}
+	}
+}
+`
+
+// profileCardData is the data of the profile card query.
+type profileCardData struct {
+	Wallet        *Wallet `json:"Wallet"`
+	TokenBalances *struct {
+		TokenBalance []TokenBalance `json:"TokenBalance"`
+	} `json:"TokenBalances"`
+	Poaps *struct {
+		Poap []Poap `json:"Poap"`
+	} `json:"Poaps"`
+}
+
+// GetProfileCard returns the avatar, primary name, social handles, follower
+// counts, most recently updated tokens and POAP count of identity, assembled
+// from a single request. The POAP count is capped at 200. With a WithSoftFail
+// context, failing sections are reported in the card Errors.
+func (client *AirstackClient) GetProfileCard(ctx context.Context, identity string) (*ProfileCard, error) {
+	var data profileCardData
+	variables := map[string]interface{}{"identity": identity, "tokens": profileCardTopTokens}
+	sections, err := client.queryComposite(ctx, profileCardQuery, variables, &data)
+	if err != nil {
+		return nil, err
+	}
+	card := data.card(client, identity)
+	card.Errors = sections
+	return card, nil
+}
+
+// card builds the profile card of identity from the query data.
+func (data profileCardData) card(client *AirstackClient, identity string) *ProfileCard {
+	card := &ProfileCard{Identity: identity}
+	if w := data.Wallet; w != nil {
+		card.Addresses = w.Addresses
+		if w.PrimaryDomain != nil {
+			card.PrimaryName = w.PrimaryDomain.Name
+		}
+		for _, s := range w.Socials {
+			card.Socials = append(card.Socials, SocialHandle{
+				DappName:      s.DappName,
+				ProfileName:   s.ProfileName,
+				FollowerCount: s.FollowerCount,
+			})
+			card.Followers += s.FollowerCount
+			if card.Avatar == "" {
+				card.Avatar = s.ProfileImage
+			}
+		}
+	}
+	if data.TokenBalances != nil {
+		balances := validate(client, "GetProfileCard", data.TokenBalances.TokenBalance)
+		card.TopTokens = client.dedupeOwner(identity, client.filterBalances(balances))
+	}
+	if data.Poaps != nil {
+		card.PoapCount = len(data.Poaps.Poap)
+	}
+	return card
+}
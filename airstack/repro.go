@@ -0,0 +1,166 @@
+package airstack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// redacted replaces the value of redacted variables in reproductions.
+const redacted = "REDACTED"
+
+// minScrubbedLength is the length below which redacted values are too
+// ambiguous to be replaced in recorded responses.
+const minScrubbedLength = 4
+
+// DefaultRedactVariables lists the identity-bearing variables of the built-in
+// queries, including the aliased values of batches and the wallets of
+// transfer filters, redacted from the reproductions written to ReproDir.
+var DefaultRedactVariables = []string{"identity", "owner", "wallet", "from", "to", "a[0-9]*"}
+
+// Reproduction is a self-contained record of a failing call, written to help
+// maintainers reproduce bug reports. It never contains the API key.
+type Reproduction struct {
+	CreatedAt  time.Time              `json:"createdAt"`
+	Operation  string                 `json:"operation"`
+	Query      string                 `json:"query"`
+	Variables  map[string]interface{} `json:"variables"`
+	StatusCode int                    `json:"statusCode"`
+	Response   json.RawMessage        `json:"response,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+}
+
+// NewReproduction records a call of query with variables and its outcome.
+// The values of the variables, or of the fields of input objects, whose name
+// matches one of the path.Match patterns in redact are replaced, as are their
+// occurrences in the recorded response and error.
+func NewReproduction(query string, variables map[string]interface{}, resp *QueryResponse, err error, redact []string) *Reproduction {
+	r := &Reproduction{
+		CreatedAt: time.Now().UTC(),
+		Operation: OperationName(query),
+		Query:     query,
+	}
+	var values []string
+	r.Variables = redactValues(variables, redact, &values)
+	if resp != nil {
+		r.StatusCode = resp.StatusCode
+		r.Response = resp.Raw
+		r.Error = resp.Error
+	}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	for _, v := range values {
+		if len(v) < minScrubbedLength {
+			continue
+		}
+		re := regexp.MustCompile(`(?i)` + regexp.QuoteMeta(v))
+		if len(r.Response) > 0 {
+			r.Response = re.ReplaceAllLiteral(r.Response, []byte(redacted))
+		}
+		r.Error = re.ReplaceAllLiteralString(r.Error, redacted)
+	}
+	return r
+}
+
+// redactValues returns a copy of variables with the values of the keys
+// matching redact replaced, recursing into input objects, and appends the
+// replaced strings to values.
+func redactValues(variables map[string]interface{}, redact []string, values *[]string) map[string]interface{} {
+	out := make(map[string]interface{}, len(variables))
+	for k, v := range variables {
+		switch {
+		case redactedName(k, redact):
+			collectStrings(v, values)
+			v = redacted
+		case isMap(v):
+			v = redactValues(v.(map[string]interface{}), redact, values)
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// redactedName reports whether name matches one of the patterns in redact.
+func redactedName(name string, redact []string) bool {
+	for _, pattern := range redact {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isMap reports whether v is an input object.
+func isMap(v interface{}) bool {
+	_, ok := v.(map[string]interface{})
+	return ok
+}
+
+// collectStrings appends the non-empty strings held by v to values.
+func collectStrings(v interface{}, values *[]string) {
+	switch v := v.(type) {
+	case string:
+		if v != "" {
+			*values = append(*values, v)
+		}
+	case []string:
+		for _, s := range v {
+			collectStrings(s, values)
+		}
+	case []interface{}:
+		for _, e := range v {
+			collectStrings(e, values)
+		}
+	case map[string]interface{}:
+		for _, e := range v {
+			collectStrings(e, values)
+		}
+	}
+}
+
+// WriteReproduction writes r as indented JSON to path.
+func WriteReproduction(path string, r *Reproduction) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// LoadReproduction reads a reproduction written by WriteReproduction.
+func LoadReproduction(path string) (*Reproduction, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r Reproduction
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// Replay sends the recorded call again through client, which may point to the
+// live API or to a fake server such as airstacktest.Server.
+func (r *Reproduction) Replay(ctx context.Context, client *AirstackClient) (*QueryResponse, error) {
+	return client.ExecuteQuery(ctx, r.Query, r.Variables)
+}
+
+// captureFailure writes a reproduction of a failed call to ReproDir.
+func (client *AirstackClient) captureFailure(query string, variables map[string]interface{}, resp *QueryResponse, err error) {
+	redact := client.RedactVariables
+	if !client.ReproUnredacted {
+		redact = append(append([]string(nil), DefaultRedactVariables...), redact...)
+	}
+	r := NewReproduction(query, variables, resp, err, redact)
+	name := fmt.Sprintf("repro-%s-%d.json", r.Operation, r.CreatedAt.UnixNano())
+	if werr := WriteReproduction(filepath.Join(client.ReproDir, name), r); werr != nil {
+		client.logger().Warn("airstack: cannot write reproduction", "error", werr)
+	}
+}
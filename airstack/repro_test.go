@@ -0,0 +1,48 @@
+package airstack_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vocdoni/go-airstack/airstack"
+	"github.com/vocdoni/go-airstack/airstacktest"
+)
+
+func TestReproductionRedactsIdentitiesByDefault(t *testing.T) {
+	s := airstacktest.NewServer()
+	defer s.Close()
+	s.Handle("GetWallet", http.StatusOK, []byte(`{"data":null,"errors":[{"message":"no wallet for Alice.eth"}]}`))
+	client := s.Client()
+	client.ReproDir = t.TempDir()
+
+	if _, err := client.GetWallet(context.Background(), "alice.eth"); err == nil {
+		t.Fatal("want the failing call to return an error")
+	}
+	files, err := filepath.Glob(filepath.Join(client.ReproDir, "repro-GetWallet-*.json"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("got reproductions %v, %v", files, err)
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(strings.ToLower(string(data)), "alice.eth") {
+		t.Fatalf("reproduction leaks the identity:\n%s", data)
+	}
+	r, err := airstack.LoadReproduction(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Variables["identity"] != "REDACTED" {
+		t.Fatalf("got identity %v, want it redacted", r.Variables["identity"])
+	}
+
+	r = airstack.NewReproduction("query Q($a0: String!) { x }", map[string]interface{}{"a0": "bob.eth", "blockchain": "base"}, nil, nil, airstack.DefaultRedactVariables)
+	if r.Variables["a0"] != "REDACTED" || r.Variables["blockchain"] != "base" {
+		t.Fatalf("got variables %v, want only the alias redacted", r.Variables)
+	}
+}
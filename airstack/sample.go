@@ -0,0 +1,138 @@
+package airstack
+
+import (
+	"container/heap"
+	"context"
+	"encoding/json"
+	"math"
+	"math/big"
+	"math/rand"
+)
+
+// HolderSampler draws a fixed-size random sample from a stream of holders
+// using reservoir sampling, so the full holder set never has to be kept in
+// memory. In weighted mode the probability of drawing a holder is
+// proportional to its amount (Efraimidis-Spirakis A-Res); otherwise every
+// holder is equally likely (Algorithm R).
+type HolderSampler struct {
+	n        int
+	weighted bool
+	rng      *rand.Rand
+	seen     int
+	uniform  []TokenHolder
+	keyed    sampleHeap
+}
+
+// NewHolderSampler returns a sampler keeping n holders. A nil rng uses the
+// global source of math/rand; pass a seeded one for reproducible draws.
+func NewHolderSampler(n int, weighted bool, rng *rand.Rand) *HolderSampler {
+	return &HolderSampler{n: n, weighted: weighted, rng: rng}
+}
+
+// float64 returns a random number in [0, 1).
+func (s *HolderSampler) float64() float64 {
+	if s.rng != nil {
+		return s.rng.Float64()
+	}
+	return rand.Float64()
+}
+
+// intn returns a random number in [0, n).
+func (s *HolderSampler) intn(n int) int {
+	if s.rng != nil {
+		return s.rng.Intn(n)
+	}
+	return rand.Intn(n)
+}
+
+// Add offers h to the sampler. In weighted mode holders without a positive
+// amount are never drawn.
+func (s *HolderSampler) Add(h TokenHolder) {
+	if s.n <= 0 {
+		return
+	}
+	if !s.weighted {
+		s.seen++
+		if len(s.uniform) < s.n {
+			s.uniform = append(s.uniform, h)
+		} else if i := s.intn(s.seen); i < s.n {
+			s.uniform[i] = h
+		}
+		return
+	}
+
+	if h.Amount == nil || h.Amount.Sign() <= 0 {
+		return
+	}
+	weight, _ := new(big.Float).SetInt(h.Amount).Float64()
+	// log(u)/w orders holders like u^(1/w) without underflowing for large
+	// weights; 1-u keeps the argument of the logarithm in (0, 1].
+	key := math.Log(1-s.float64()) / weight
+	if len(s.keyed) < s.n {
+		heap.Push(&s.keyed, sampleItem{holder: h, key: key})
+	} else if key > s.keyed[0].key {
+		s.keyed[0] = sampleItem{holder: h, key: key}
+		heap.Fix(&s.keyed, 0)
+	}
+}
+
+// Sample returns the holders drawn so far.
+func (s *HolderSampler) Sample() []TokenHolder {
+	if !s.weighted {
+		return append([]TokenHolder(nil), s.uniform...)
+	}
+	out := make([]TokenHolder, len(s.keyed))
+	for i, item := range s.keyed {
+		out[i] = item.holder
+	}
+	return out
+}
+
+// sampleItem is a holder kept by a weighted sampler with its random key.
+type sampleItem struct {
+	holder TokenHolder
+	key    float64
+}
+
+// sampleHeap is a min-heap of sample items by key.
+type sampleHeap []sampleItem
+
+func (h sampleHeap) Len() int            { return len(h) }
+func (h sampleHeap) Less(i, j int) bool  { return h[i].key < h[j].key }
+func (h sampleHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *sampleHeap) Push(x interface{}) { *h = append(*h, x.(sampleItem)) }
+func (h *sampleHeap) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
+
+// SampleTokenHolders streams the balances of token page by page and draws n of
+// them with a HolderSampler. Balances are sampled as returned by Airstack, so
+// an owner of several token ids of an NFT contract takes part once per token
+// id.
+func (client *AirstackClient) SampleTokenHolders(ctx context.Context, token Token, n int, weighted bool, rng *rand.Rand) ([]TokenHolder, error) {
+	sampler := NewHolderSampler(n, weighted, rng)
+	err := client.paginate(ctx, holdersQuery("SampleTokenHolders", token), "", func(items json.RawMessage, _ string) error {
+		if len(items) == 0 || string(items) == "null" {
+			return nil
+		}
+		var rows []tokenHolderBalance
+		if err := json.Unmarshal(items, &rows); err != nil {
+			return err
+		}
+		for _, row := range validate(client, "SampleTokenHolders", rows) {
+			amount, ok := new(big.Int).SetString(row.Amount, 10)
+			if !ok {
+				continue
+			}
+			sampler.Add(TokenHolder{Address: row.address(), Amount: amount})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sampler.Sample(), nil
+}
@@ -0,0 +1,218 @@
+package airstack
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"sort"
+)
+
+// builtinFields lists, per GraphQL type, the fields selected by the built-in
+// queries of this package. It is matched against the live schema to detect
+// deprecations and changes affecting the typed models.
+var builtinFields = map[string][]string{
+	"TokenBalance": {"owner", "amount", "formattedAmount", "blockchain", "tokenAddress", "tokenId"},
+	"Wallet":       {"identity", "addresses", "primaryDomain", "domains", "socials"},
+	"Domain":       {"name", "resolvedAddress"},
+	"Social": {"dappName", "profileName", "profileImage", "userId", "followerCount",
+		"followingCount", "userAssociatedAddresses"},
+	"TokenTransfer": {"type", "from", "to", "blockchain", "tokenAddress", "tokenId", "amount",
+		"blockNumber", "blockTimestamp", "transactionHash"},
+	"PoapEvent": {"eventId", "eventName", "startDate", "endDate", "country", "city",
+		"isVirtualEvent"},
+	"Poap":           {"eventId", "tokenId", "poapEvent"},
+	"Snapshot":       {"owner", "amount", "tokenId"},
+	"SocialFollower": {"followerAddress"},
+	"PageInfo":       {"nextCursor", "prevCursor"},
+}
+
+// typeRef is an introspected GraphQL type reference.
+type typeRef struct {
+	Kind   string   `json:"kind"`
+	Name   string   `json:"name"`
+	OfType *typeRef `json:"ofType"`
+}
+
+// String renders the reference in GraphQL notation, e.g. "[Address!]".
+func (t *typeRef) String() string {
+	if t == nil {
+		return ""
+	}
+	switch t.Kind {
+	case "NON_NULL":
+		return t.OfType.String() + "!"
+	case "LIST":
+		return "[" + t.OfType.String() + "]"
+	}
+	return t.Name
+}
+
+// schemaField is a field of an introspected GraphQL type.
+type schemaField struct {
+	Name              string   `json:"name"`
+	IsDeprecated      bool     `json:"isDeprecated"`
+	DeprecationReason string   `json:"deprecationReason"`
+	Type              *typeRef `json:"type"`
+}
+
+// schemaType is an introspected GraphQL object type.
+type schemaType struct {
+	Name   string        `json:"name"`
+	Fields []schemaField `json:"fields"`
+}
+
+const introspectionQuery = `
+query IntrospectSchema {
+	__schema {
+		types {
+			name
+			fields(includeDeprecated: true) {
+				name
+				isDeprecated
+				deprecationReason
+				type {
+					kind
+					name
+					ofType {
+						kind
+						name
+						ofType {
+							kind
+							name
+							ofType {
+								kind
+								name
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+`
+
+// introspect fetches the object types of the live schema.
+func (client *AirstackClient) introspect(ctx context.Context) ([]schemaType, error) {
+	var respData struct {
+		Schema struct {
+			Types []schemaType `json:"types"`
+		} `json:"__schema"`
+	}
+	if err := client.query(ctx, introspectionQuery, nil, &respData); err != nil {
+		return nil, err
+	}
+	return respData.Schema.Types, nil
+}
+
+//go:embed schema_snapshot.json
+var vendoredSnapshot []byte
+
+// SchemaSnapshot maps the types backing the typed models of this package to
+// their fields and the GraphQL type of each field.
+type SchemaSnapshot struct {
+	Types map[string]map[string]string `json:"types"`
+}
+
+// VendoredSchemaSnapshot returns the schema snapshot the typed models were
+// written against.
+func VendoredSchemaSnapshot() (*SchemaSnapshot, error) {
+	var snapshot SchemaSnapshot
+	if err := json.Unmarshal(vendoredSnapshot, &snapshot); err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// SnapshotSchema introspects the live schema and returns the snapshot of the
+// types listed in the vendored snapshot, suitable to refresh it.
+func (client *AirstackClient) SnapshotSchema(ctx context.Context) (*SchemaSnapshot, error) {
+	vendored, err := VendoredSchemaSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	types, err := client.introspect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	live := &SchemaSnapshot{Types: make(map[string]map[string]string)}
+	for _, t := range types {
+		if _, ok := vendored.Types[t.Name]; !ok {
+			continue
+		}
+		fields := make(map[string]string, len(t.Fields))
+		for _, f := range t.Fields {
+			fields[f.Name] = f.Type.String()
+		}
+		live.Types[t.Name] = fields
+	}
+	return live, nil
+}
+
+// Schema change kinds.
+const (
+	SchemaFieldAdded   = "added"
+	SchemaFieldRemoved = "removed"
+	SchemaFieldChanged = "changed"
+	SchemaTypeRemoved  = "type-removed"
+)
+
+// SchemaChange is a difference between the vendored and the live schema.
+// UsedByModels is true when a built-in query selects the field.
+type SchemaChange struct {
+	Type         string `json:"type"`
+	Field        string `json:"field,omitempty"`
+	Kind         string `json:"kind"`
+	Old          string `json:"old,omitempty"`
+	New          string `json:"new,omitempty"`
+	UsedByModels bool   `json:"usedByModels"`
+}
+
+// DiffSchemaSnapshots lists the changes from old to updated, sorted by type
+// and field.
+func DiffSchemaSnapshots(old, updated *SchemaSnapshot) []SchemaChange {
+	var changes []SchemaChange
+	for typeName, oldFields := range old.Types {
+		newFields, ok := updated.Types[typeName]
+		if !ok {
+			changes = append(changes, SchemaChange{Type: typeName, Kind: SchemaTypeRemoved, UsedByModels: len(builtinFields[typeName]) > 0})
+			continue
+		}
+		used := builtinFields[typeName]
+		for field, oldType := range oldFields {
+			newType, ok := newFields[field]
+			switch {
+			case !ok:
+				changes = append(changes, SchemaChange{Type: typeName, Field: field, Kind: SchemaFieldRemoved, Old: oldType, UsedByModels: contains(used, field)})
+			case newType != oldType:
+				changes = append(changes, SchemaChange{Type: typeName, Field: field, Kind: SchemaFieldChanged, Old: oldType, New: newType, UsedByModels: contains(used, field)})
+			}
+		}
+		for field, newType := range newFields {
+			if _, ok := oldFields[field]; !ok {
+				changes = append(changes, SchemaChange{Type: typeName, Field: field, Kind: SchemaFieldAdded, New: newType, UsedByModels: contains(used, field)})
+			}
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Type != changes[j].Type {
+			return changes[i].Type < changes[j].Type
+		}
+		return changes[i].Field < changes[j].Field
+	})
+	return changes
+}
+
+// DiffSchema introspects the live schema and diffs it against the vendored
+// snapshot.
+func (client *AirstackClient) DiffSchema(ctx context.Context) ([]SchemaChange, error) {
+	vendored, err := VendoredSchemaSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	live, err := client.SnapshotSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return DiffSchemaSnapshots(vendored, live), nil
+}
@@ -0,0 +1,155 @@
+package airstack
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+)
+
+// SnapshotPoint is the point in time of a snapshot, given either as a block
+// number or as a date. BlockNumber takes precedence when both are set.
+type SnapshotPoint struct {
+	BlockNumber int64
+	Timestamp   time.Time
+}
+
+// date returns the UTC day queried for p, at midnight, or the zero time when
+// p is a block snapshot. Snapshots are filtered by date, so the time of day of
+// Timestamp is not part of the query.
+func (p SnapshotPoint) date() time.Time {
+	if p.BlockNumber > 0 || p.Timestamp.IsZero() {
+		return time.Time{}
+	}
+	return p.Timestamp.UTC().Truncate(24 * time.Hour)
+}
+
+// graphQL returns the SnapshotFilter input selecting token at p.
+func (p SnapshotPoint) graphQL(token Token) (map[string]interface{}, error) {
+	filter := map[string]interface{}{"tokenAddress": map[string]interface{}{"_eq": token.Address}}
+	switch {
+	case p.BlockNumber > 0:
+		filter["blockNumber"] = map[string]interface{}{"_eq": p.BlockNumber}
+	case !p.Timestamp.IsZero():
+		filter["date"] = map[string]interface{}{"_eq": p.date().Format(time.DateOnly)}
+	default:
+		return nil, errors.New("airstack: snapshot point needs a block number or a timestamp")
+	}
+	return filter, nil
+}
+
+const snapshotHoldersQuery = `
+query GetSnapshotHolders($filter: SnapshotFilter!, $blockchain: TokenBlockchain!, $limit: Int, $cursor: String) {
+	Snapshots(input: {filter: $filter, blockchain: $blockchain, limit: $limit, cursor: $cursor}) {
+		Snapshot {
+			owner {
+				identity
+				addresses
+			}
+			amount
+			tokenId
+		}
+		pageInfo {
+			nextCursor
+			prevCursor
+		}
+	}
+}
+`
+
+// GetSnapshotHolders fetches the holders of token as of at, aggregated per
+// owner and sorted by descending amount.
+func (client *AirstackClient) GetSnapshotHolders(ctx context.Context, token Token, at SnapshotPoint) ([]TokenHolder, error) {
+	filter, err := at.graphQL(token)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := fetchAll[tokenHolderBalance](ctx, client, pagedQuery{
+		helper:     "GetSnapshotHolders",
+		blockchain: token.Blockchain,
+		query:      snapshotHoldersQuery,
+		root:       QuerySnapshots,
+		field:      "Snapshot",
+		variables: map[string]interface{}{
+			"filter":     filter,
+			"blockchain": token.Blockchain,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return aggregateHolders(rows), nil
+}
+
+// CensusMetadata records how a census was built, for auditability. Timestamp
+// is the UTC day queried for date snapshots, at midnight, and is zero for
+// block snapshots.
+type CensusMetadata struct {
+	Token       Token
+	BlockNumber int64
+	Timestamp   time.Time
+	BuiltAt     time.Time
+	Members     int
+	TotalWeight *big.Int
+}
+
+// Census is a set of weighted members together with how it was built.
+type Census struct {
+	Members  []CensusMember
+	Metadata CensusMetadata
+}
+
+// SnapshotCensusSource is a CensusSource weighting every holder of a token by
+// its balance as of a past block or date, such as the snapshot of a proposal.
+type SnapshotCensusSource struct {
+	client   *AirstackClient
+	token    Token
+	at       SnapshotPoint
+	metadata CensusMetadata
+}
+
+// NewSnapshotCensusSource returns a SnapshotCensusSource over the holders of
+// token at the given point.
+func NewSnapshotCensusSource(client *AirstackClient, token Token, at SnapshotPoint) *SnapshotCensusSource {
+	return &SnapshotCensusSource{client: client, token: token, at: at}
+}
+
+// Members returns the holders of the token at the snapshot point, weighted by
+// their balance and sorted by descending weight.
+func (s *SnapshotCensusSource) Members(ctx context.Context) ([]CensusMember, error) {
+	holders, err := s.client.GetSnapshotHolders(ctx, s.token, s.at)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]CensusMember, len(holders))
+	total := new(big.Int)
+	for i, h := range holders {
+		members[i] = CensusMember{Address: h.Address, Weight: h.Amount, Sources: []string{h.Address}}
+		total.Add(total, h.Amount)
+	}
+	s.metadata = CensusMetadata{
+		Token:       s.token,
+		BlockNumber: s.at.BlockNumber,
+		Timestamp:   s.at.date(),
+		BuiltAt:     time.Now().UTC(),
+		Members:     len(members),
+		TotalWeight: total,
+	}
+	return members, nil
+}
+
+// Metadata returns the metadata of the last Members call.
+func (s *SnapshotCensusSource) Metadata() CensusMetadata {
+	return s.metadata
+}
+
+// BuildSnapshotCensus builds the weighted census of the holders of token as
+// of at, recording the snapshot block or date in its metadata.
+func (client *AirstackClient) BuildSnapshotCensus(ctx context.Context, token Token, at SnapshotPoint) (*Census, error) {
+	source := NewSnapshotCensusSource(client, token, at)
+	members, err := source.Members(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Census{Members: members, Metadata: source.Metadata()}, nil
+}
@@ -0,0 +1,36 @@
+package airstack_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vocdoni/go-airstack/airstack"
+	"github.com/vocdoni/go-airstack/airstacktest"
+)
+
+func TestSnapshotCensusRecordsQueriedDate(t *testing.T) {
+	s := airstacktest.NewServer()
+	defer s.Close()
+	s.HandleFunc("GetSnapshotHolders", (&airstacktest.PaginationFixture{
+		Root:  "Snapshots",
+		Field: "Snapshot",
+		Items: airstacktest.TokenBalanceItems(3),
+	}).Handler())
+
+	at := time.Date(2024, 3, 5, 23, 30, 0, 0, time.FixedZone("UTC-2", -2*3600))
+	census, err := s.Client().BuildSnapshotCensus(context.Background(), testToken, airstack.SnapshotPoint{Timestamp: at})
+	if err != nil {
+		t.Fatal(err)
+	}
+	filter := s.Requests()[0].Variables["filter"].(map[string]interface{})
+	if date := filter["date"].(map[string]interface{})["_eq"]; date != "2024-03-06" {
+		t.Fatalf("queried date %v, want the UTC day 2024-03-06", date)
+	}
+	if want := time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC); !census.Metadata.Timestamp.Equal(want) {
+		t.Fatalf("recorded timestamp %v, want the queried day %v", census.Metadata.Timestamp, want)
+	}
+	if census.Metadata.Members != 3 || census.Metadata.TotalWeight.Int64() != 6 {
+		t.Fatalf("got metadata %+v", census.Metadata)
+	}
+}
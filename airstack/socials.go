@@ -0,0 +1,44 @@
+package airstack
+
+import "context"
+
+// Social represents a social profile linked to an identity.
+type Social struct {
+	DappName                string   `json:"dappName"`
+	ProfileName             string   `json:"profileName"`
+	ProfileImage            string   `json:"profileImage"`
+	UserId                  string   `json:"userId"`
+	FollowerCount           int      `json:"followerCount"`
+	FollowingCount          int      `json:"followingCount"`
+	UserAssociatedAddresses []string `json:"userAssociatedAddresses"`
+}
+
+const socialsQuery = `
+query GetSocials($identity: Identity!) {
+	Socials(input: {filter: {identity: {_eq: $identity}}, blockchain: ethereum}) {
+		Social {
+			dappName
+			profileName
+			profileImage
+			userId
+			followerCount
+			followingCount
+			userAssociatedAddresses
+		}
+	}
+}
+`
+
+// GetSocials returns the social profiles (Farcaster, Lens) linked to identity,
+// which may be an address, an ENS name or a dapp identity such as "fc_fid:1".
+func (client *AirstackClient) GetSocials(ctx context.Context, identity string) ([]Social, error) {
+	var respData struct {
+		Socials struct {
+			Social []Social `json:"Social"`
+		} `json:"Socials"`
+	}
+	if err := client.query(ctx, socialsQuery, map[string]interface{}{"identity": identity}, &respData); err != nil {
+		return nil, err
+	}
+	return respData.Socials.Social, nil
+}
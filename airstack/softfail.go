@@ -0,0 +1,63 @@
+package airstack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// softFailKey is the context key enabling soft-fail mode.
+type softFailKey struct{}
+
+// WithSoftFail returns a context in which composite helpers such as GetWallet
+// and GetProfileCard return partial results when some of their sections fail,
+// listing the failures in the result Errors instead of failing the call.
+func WithSoftFail(ctx context.Context) context.Context {
+	return context.WithValue(ctx, softFailKey{}, true)
+}
+
+// isSoftFail reports whether ctx was created by WithSoftFail.
+func isSoftFail(ctx context.Context) bool {
+	softFail, _ := ctx.Value(softFailKey{}).(bool)
+	return softFail
+}
+
+// SectionError is the failure of one section of a composite result. Section
+// is the path of the failing field, e.g. "Wallet.socials" or "Poaps".
+type SectionError struct {
+	Section string
+	Err     error
+}
+
+func (e SectionError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Section, e.Err)
+}
+
+// queryComposite executes a composite query and decodes its data into out.
+// Outside soft-fail mode it behaves like query. In soft-fail mode, GraphQL
+// errors scoped to a field are returned as section errors alongside the
+// partial data, while errors not scoped to any field still fail the call.
+func (client *AirstackClient) queryComposite(ctx context.Context, query string, variables map[string]interface{}, out interface{}) ([]SectionError, error) {
+	if !isSoftFail(ctx) {
+		return nil, client.query(ctx, query, variables, out)
+	}
+	resp, err := client.ExecuteQuery(ctx, query, variables)
+	if err != nil {
+		return nil, err
+	}
+	data, fieldErrs, err := responseData(resp)
+	if err != nil {
+		return nil, err
+	}
+	var sections []SectionError
+	for _, e := range fieldErrs {
+		sections = append(sections, SectionError{Section: strings.Join(e.path, "."), Err: e.err})
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			return nil, err
+		}
+	}
+	return sections, nil
+}
@@ -0,0 +1,37 @@
+package airstack_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/vocdoni/go-airstack/airstack"
+	"github.com/vocdoni/go-airstack/airstacktest"
+)
+
+const partialWallet = `{
+	"data": {"Wallet": {"identity": "alice.eth", "addresses": ["0x01"], "socials": null}},
+	"errors": [{"message": "socials unavailable", "path": ["Wallet", "socials"]}]
+}`
+
+func TestSoftFailWalletNotCached(t *testing.T) {
+	s := airstacktest.NewServer()
+	defer s.Close()
+	s.Handle("GetWallet", http.StatusOK, []byte(partialWallet))
+	cache := airstack.NewProfileCache(s.Client(), time.Hour)
+
+	wallet, err := cache.Wallet(airstack.WithSoftFail(context.Background()), "alice.eth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(wallet.Errors) != 1 || wallet.Errors[0].Section != "Wallet.socials" {
+		t.Fatalf("got section errors %v, want Wallet.socials", wallet.Errors)
+	}
+	if _, err := cache.Wallet(context.Background(), "alice.eth"); err == nil {
+		t.Fatal("got the cached partial wallet outside soft-fail mode")
+	}
+	if n := len(s.Requests()); n != 2 {
+		t.Fatalf("got %d requests, want the partial wallet not to be cached", n)
+	}
+}
@@ -0,0 +1,176 @@
+package airstack
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrNotFound is returned by Store implementations when a key does not exist.
+var ErrNotFound = errors.New("airstack: key not found")
+
+// Store persists the state of long-running helpers, such as sync progress,
+// watcher samples and resume tokens, under namespaced keys.
+type Store interface {
+	Get(ctx context.Context, namespace, key string) ([]byte, error)
+	Put(ctx context.Context, namespace, key string, value []byte) error
+	Delete(ctx context.Context, namespace, key string) error
+}
+
+// MemoryStore is a Store kept in memory, suitable for tests and short-lived
+// processes.
+type MemoryStore struct {
+	mu     sync.Mutex
+	values map[string]map[string][]byte
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{values: make(map[string]map[string][]byte)}
+}
+
+// Get returns the value stored under namespace and key.
+func (s *MemoryStore) Get(_ context.Context, namespace, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, ok := s.values[namespace][key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return append([]byte(nil), value...), nil
+}
+
+// Put stores value under namespace and key.
+func (s *MemoryStore) Put(_ context.Context, namespace, key string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.values[namespace] == nil {
+		s.values[namespace] = make(map[string][]byte)
+	}
+	s.values[namespace][key] = append([]byte(nil), value...)
+	return nil
+}
+
+// Delete removes namespace and key. Deleting a missing key is not an error.
+func (s *MemoryStore) Delete(_ context.Context, namespace, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.values[namespace], key)
+	return nil
+}
+
+// FileStore is a Store keeping one file per key, in a directory per namespace.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore returns a FileStore rooted at dir, creating it if needed.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// path returns the file holding namespace and key.
+func (s *FileStore) path(namespace, key string) string {
+	return filepath.Join(s.dir, url.PathEscape(namespace), url.PathEscape(key))
+}
+
+// Get returns the value stored under namespace and key.
+func (s *FileStore) Get(_ context.Context, namespace, key string) ([]byte, error) {
+	value, err := os.ReadFile(s.path(namespace, key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return value, err
+}
+
+// Put stores value under namespace and key. The file is replaced atomically so
+// a crash never leaves a truncated value behind.
+func (s *FileStore) Put(_ context.Context, namespace, key string, value []byte) error {
+	path := s.path(namespace, key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(value); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Delete removes namespace and key. Deleting a missing key is not an error.
+func (s *FileStore) Delete(_ context.Context, namespace, key string) error {
+	err := os.Remove(s.path(namespace, key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// SQLiteStore is a Store backed by a table in a SQLite database. The caller
+// opens db with the SQLite driver of its choice, e.g. modernc.org/sqlite or
+// github.com/mattn/go-sqlite3.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore returns a SQLiteStore using db, creating its table if needed.
+func NewSQLiteStore(ctx context.Context, db *sql.DB) (*SQLiteStore, error) {
+	_, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS airstack_store (
+		namespace TEXT NOT NULL,
+		key TEXT NOT NULL,
+		value BLOB NOT NULL,
+		PRIMARY KEY (namespace, key)
+	)`)
+	if err != nil {
+		return nil, err
+	}
+	return &SQLiteStore{db: db}, nil
+}
+
+// Get returns the value stored under namespace and key.
+func (s *SQLiteStore) Get(ctx context.Context, namespace, key string) ([]byte, error) {
+	var value []byte
+	err := s.db.QueryRowContext(ctx,
+		`SELECT value FROM airstack_store WHERE namespace = ? AND key = ?`,
+		namespace, key).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	return value, err
+}
+
+// Put stores value under namespace and key.
+func (s *SQLiteStore) Put(ctx context.Context, namespace, key string, value []byte) error {
+	if value == nil {
+		// database/sql binds a nil slice as NULL, rejected by the value column.
+		value = []byte{}
+	}
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO airstack_store (namespace, key, value) VALUES (?, ?, ?)
+		ON CONFLICT (namespace, key) DO UPDATE SET value = excluded.value`,
+		namespace, key, value)
+	return err
+}
+
+// Delete removes namespace and key. Deleting a missing key is not an error.
+func (s *SQLiteStore) Delete(ctx context.Context, namespace, key string) error {
+	_, err := s.db.ExecContext(ctx,
+		`DELETE FROM airstack_store WHERE namespace = ? AND key = ?`,
+		namespace, key)
+	return err
+}
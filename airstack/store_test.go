@@ -0,0 +1,61 @@
+package airstack_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/vocdoni/go-airstack/airstack"
+)
+
+func TestStores(t *testing.T) {
+	fileStore, err := airstack.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, store := range map[string]airstack.Store{
+		"memory": airstack.NewMemoryStore(),
+		"file":   fileStore,
+	} {
+		t.Run(name, func(t *testing.T) { testStore(t, store) })
+	}
+}
+
+// testStore runs the Store contract against store.
+func testStore(t *testing.T, store airstack.Store) {
+	ctx := context.Background()
+	const key = "/tmp/exports/holders.csv"
+
+	if _, err := store.Get(ctx, "ns", key); !errors.Is(err, airstack.ErrNotFound) {
+		t.Fatalf("Get of a missing key: %v, want ErrNotFound", err)
+	}
+	if err := store.Put(ctx, "ns", key, []byte("one")); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Put(ctx, "ns", key, []byte("two")); err != nil {
+		t.Fatal(err)
+	}
+	if value, err := store.Get(ctx, "ns", key); err != nil || string(value) != "two" {
+		t.Fatalf("Get = %q, %v, want the last value put", value, err)
+	}
+	if _, err := store.Get(ctx, "other", key); !errors.Is(err, airstack.ErrNotFound) {
+		t.Fatalf("Get in another namespace: %v, want ErrNotFound", err)
+	}
+
+	if err := store.Put(ctx, "ns", "empty", nil); err != nil {
+		t.Fatalf("Put of a nil value: %v", err)
+	}
+	if value, err := store.Get(ctx, "ns", "empty"); err != nil || len(value) != 0 {
+		t.Fatalf("Get of a nil value = %q, %v, want an empty value", value, err)
+	}
+
+	if err := store.Delete(ctx, "ns", key); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get(ctx, "ns", key); !errors.Is(err, airstack.ErrNotFound) {
+		t.Fatalf("Get of a deleted key: %v, want ErrNotFound", err)
+	}
+	if err := store.Delete(ctx, "ns", key); err != nil {
+		t.Fatalf("Delete of a missing key: %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package airstack
+
+import (
+	"strings"
+	"sync"
+)
+
+// TokenFilter holds the token contracts helpers always keep or drop from
+// balance and transfer results, such as known scam contracts. Entries are
+// either an address, matching on every blockchain, or "blockchain:address".
+type TokenFilter struct {
+	mu    sync.RWMutex
+	allow map[string]bool
+	deny  map[string]bool
+}
+
+// NewTokenFilter returns a filter allowing every token.
+func NewTokenFilter() *TokenFilter {
+	return &TokenFilter{allow: make(map[string]bool), deny: make(map[string]bool)}
+}
+
+// Allow adds entries to the allowlist. Once the allowlist is not empty, only
+// the tokens it lists are kept.
+func (f *TokenFilter) Allow(entries ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, e := range entries {
+		f.allow[strings.ToLower(e)] = true
+	}
+}
+
+// Deny adds entries to the denylist. Denied tokens are dropped even when
+// allowlisted.
+func (f *TokenFilter) Deny(entries ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, e := range entries {
+		f.deny[strings.ToLower(e)] = true
+	}
+}
+
+// Allowed reports whether the token at address on blockchain is kept.
+func (f *TokenFilter) Allowed(blockchain, address string) bool {
+	address = strings.ToLower(address)
+	scoped := strings.ToLower(blockchain) + ":" + address
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if f.deny[address] || f.deny[scoped] {
+		return false
+	}
+	return len(f.allow) == 0 || f.allow[address] || f.allow[scoped]
+}
+
+// filterTokens drops the records whose token the client TokenFilter rejects.
+func filterTokens[T any](client *AirstackClient, records []T, token func(T) (blockchain, address string)) []T {
+	if client.TokenFilter == nil {
+		return records
+	}
+	kept := records[:0]
+	for _, r := range records {
+		if client.TokenFilter.Allowed(token(r)) {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
+
+// filterBalances applies the client TokenFilter to balances.
+func (client *AirstackClient) filterBalances(balances []TokenBalance) []TokenBalance {
+	return filterTokens(client, balances, func(b TokenBalance) (string, string) {
+		return b.Blockchain, b.TokenAddress
+	})
+}
+
+// filterTransfers applies the client TokenFilter to transfers.
+func (client *AirstackClient) filterTransfers(transfers []TokenTransfer) []TokenTransfer {
+	return filterTokens(client, transfers, func(t TokenTransfer) (string, string) {
+		return t.Blockchain, t.TokenAddress
+	})
+}
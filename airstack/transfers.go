@@ -0,0 +1,180 @@
+package airstack
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// TransferType is the Airstack classification of a token transfer.
+type TransferType string
+
+// Transfer types.
+const (
+	TransferMint     TransferType = "MINT"
+	TransferBurn     TransferType = "BURN"
+	TransferTransfer TransferType = "TRANSFER"
+)
+
+// TokenTransfer represents a token transfer between two wallets.
+type TokenTransfer struct {
+	Type            TransferType
+	From            string
+	To              string
+	Blockchain      string
+	TokenAddress    string
+	TokenId         string
+	Amount          string
+	BlockNumber     int64
+	BlockTimestamp  time.Time
+	TransactionHash string
+}
+
+// tokenTransferRow is a TokenTransfer as returned by the transfers query.
+type tokenTransferRow struct {
+	Type TransferType `json:"type"`
+	From struct {
+		Identity string `json:"identity"`
+	} `json:"from"`
+	To struct {
+		Identity string `json:"identity"`
+	} `json:"to"`
+	Blockchain      string    `json:"blockchain"`
+	TokenAddress    string    `json:"tokenAddress"`
+	TokenId         string    `json:"tokenId"`
+	Amount          string    `json:"amount"`
+	BlockNumber     int64     `json:"blockNumber"`
+	BlockTimestamp  time.Time `json:"blockTimestamp"`
+	TransactionHash string    `json:"transactionHash"`
+}
+
+func (r tokenTransferRow) transfer() TokenTransfer {
+	return TokenTransfer{
+		Type:            r.Type,
+		From:            strings.ToLower(r.From.Identity),
+		To:              strings.ToLower(r.To.Identity),
+		Blockchain:      r.Blockchain,
+		TokenAddress:    strings.ToLower(r.TokenAddress),
+		TokenId:         r.TokenId,
+		Amount:          r.Amount,
+		BlockNumber:     r.BlockNumber,
+		BlockTimestamp:  r.BlockTimestamp,
+		TransactionHash: r.TransactionHash,
+	}
+}
+
+// TransferFilter selects token transfers. Empty fields are not filtered on.
+type TransferFilter struct {
+	Blockchain   string
+	Type         TransferType
+	From         string
+	To           string
+	TokenAddress string
+	Since        time.Time
+	Until        time.Time
+}
+
+// graphQL returns the TokenTransferFilter input matching f.
+func (f TransferFilter) graphQL() map[string]interface{} {
+	filter := make(map[string]interface{})
+	if f.Type != "" {
+		filter["type"] = map[string]interface{}{"_eq": f.Type}
+	}
+	if f.From != "" {
+		filter["from"] = map[string]interface{}{"_eq": f.From}
+	}
+	if f.To != "" {
+		filter["to"] = map[string]interface{}{"_eq": f.To}
+	}
+	if f.TokenAddress != "" {
+		filter["tokenAddress"] = map[string]interface{}{"_eq": f.TokenAddress}
+	}
+	timestamp := make(map[string]interface{})
+	if !f.Since.IsZero() {
+		timestamp["_gte"] = f.Since.UTC().Format(time.RFC3339)
+	}
+	if !f.Until.IsZero() {
+		timestamp["_lte"] = f.Until.UTC().Format(time.RFC3339)
+	}
+	if len(timestamp) > 0 {
+		filter["blockTimestamp"] = timestamp
+	}
+	return filter
+}
+
+const tokenTransfersQuery = `
+query GetTokenTransfers($filter: TokenTransferFilter!, $blockchain: TokenBlockchain!, $limit: Int, $cursor: String) {
+	TokenTransfers(
+		input: {filter: $filter, blockchain: $blockchain, limit: $limit, cursor: $cursor, order: {blockTimestamp: ASC}}
+	) {
+		TokenTransfer {
+			type
+			from {
+				identity
+			}
+			to {
+				identity
+			}
+			blockchain
+			tokenAddress
+			tokenId
+			amount
+			blockNumber
+			blockTimestamp
+			transactionHash
+		}
+		pageInfo {
+			nextCursor
+			prevCursor
+		}
+	}
+}
+`
+
+// transfersQuery returns the paginated query listing the transfers matching
+// filter, oldest first, run on behalf of helper.
+func transfersQuery(helper string, filter TransferFilter) pagedQuery {
+	return pagedQuery{
+		helper:     helper,
+		blockchain: filter.Blockchain,
+		query:      tokenTransfersQuery,
+		root:       QueryTokenTransfers,
+		field:      "TokenTransfer",
+		variables: map[string]interface{}{
+			"filter":     filter.graphQL(),
+			"blockchain": filter.Blockchain,
+		},
+	}
+}
+
+// GetTokenTransfers fetches every transfer matching filter, oldest first.
+func (client *AirstackClient) GetTokenTransfers(ctx context.Context, filter TransferFilter) ([]TokenTransfer, error) {
+	return client.getTokenTransfers(ctx, "GetTokenTransfers", filter)
+}
+
+// getTokenTransfers implements GetTokenTransfers on behalf of helper.
+func (client *AirstackClient) getTokenTransfers(ctx context.Context, helper string, filter TransferFilter) ([]TokenTransfer, error) {
+	rows, err := fetchAll[tokenTransferRow](ctx, client, transfersQuery(helper, filter))
+	if err != nil {
+		return nil, err
+	}
+	transfers := make([]TokenTransfer, len(rows))
+	for i, row := range rows {
+		transfers[i] = row.transfer()
+	}
+	return client.filterTransfers(transfers), nil
+}
+
+// GetMints fetches the mints matching filter, e.g. every mint of a contract
+// with TokenAddress or the mints received by a wallet with To.
+func (client *AirstackClient) GetMints(ctx context.Context, filter TransferFilter) ([]TokenTransfer, error) {
+	filter.Type = TransferMint
+	return client.getTokenTransfers(ctx, "GetMints", filter)
+}
+
+// GetBurns fetches the burns matching filter, e.g. every burn of a contract
+// with TokenAddress or the tokens burnt by a wallet with From.
+func (client *AirstackClient) GetBurns(ctx context.Context, filter TransferFilter) ([]TokenTransfer, error) {
+	filter.Type = TransferBurn
+	return client.getTokenTransfers(ctx, "GetBurns", filter)
+}
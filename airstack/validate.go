@@ -0,0 +1,156 @@
+package airstack
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ValidationAction is what happens to a record failing a validator.
+type ValidationAction int
+
+const (
+	// ValidationFlag keeps the record and reports the issue.
+	ValidationFlag ValidationAction = iota
+	// ValidationReject drops the record and reports the issue.
+	ValidationReject
+)
+
+// Validator checks a field of the records decoded by the helpers. Field names
+// follow the GraphQL fields: "amount", "tokenAddress", "tokenId", "owner" for
+// balances and "from", "to" for transfers. Records without the field are not
+// checked.
+type Validator struct {
+	Name   string
+	Field  string
+	Check  func(value string) error
+	Action ValidationAction
+}
+
+// ValidationIssue reports a record failing a validator.
+type ValidationIssue struct {
+	Helper    string
+	Validator string
+	Field     string
+	Value     string
+	Err       error
+	Rejected  bool
+}
+
+// validatable is implemented by the records validators apply to.
+type validatable interface {
+	validationFields() map[string]string
+}
+
+func (b TokenBalance) validationFields() map[string]string {
+	return map[string]string{"amount": b.Amount, "tokenAddress": b.TokenAddress, "tokenId": b.TokenId}
+}
+
+func (b tokenHolderBalance) validationFields() map[string]string {
+	return map[string]string{"amount": b.Amount, "owner": b.address(), "tokenId": b.TokenId}
+}
+
+func (r tokenTransferRow) validationFields() map[string]string {
+	return map[string]string{
+		"amount":       r.Amount,
+		"tokenAddress": r.TokenAddress,
+		"tokenId":      r.TokenId,
+		"from":         r.From.Identity,
+		"to":           r.To.Identity,
+	}
+}
+
+// AmountIsInteger returns a validator requiring "amount" to be a base-10
+// integer.
+func AmountIsInteger(action ValidationAction) Validator {
+	return Validator{
+		Name:   "amount-is-integer",
+		Field:  "amount",
+		Action: action,
+		Check: func(value string) error {
+			if _, ok := new(big.Int).SetString(value, 10); !ok {
+				return errors.New("amount is not an integer")
+			}
+			return nil
+		},
+	}
+}
+
+// AddressIsHex returns a validator requiring field to be a 0x-prefixed
+// 20-byte hex address.
+func AddressIsHex(field string, action ValidationAction) Validator {
+	return Validator{
+		Name:   "address-is-hex",
+		Field:  field,
+		Action: action,
+		Check: func(value string) error {
+			if !addressRe.MatchString(value) {
+				return fmt.Errorf("%s is not a hex address", field)
+			}
+			return nil
+		},
+	}
+}
+
+// RegisterValidator adds v to the validators run on every record decoded by
+// the helpers. Issues are passed to OnValidationIssue, or logged as warnings
+// when it is nil.
+func (client *AirstackClient) RegisterValidator(v Validator) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	client.validators = append(client.validators, v)
+}
+
+// validate runs the registered validators on records, returning the records
+// that were not rejected.
+func validate[T any](client *AirstackClient, helper string, records []T) []T {
+	client.mu.Lock()
+	validators := client.validators
+	client.mu.Unlock()
+	if len(validators) == 0 {
+		return records
+	}
+
+	kept := records[:0]
+	for _, record := range records {
+		v, ok := any(record).(validatable)
+		if !ok || client.checkRecord(helper, validators, v.validationFields()) {
+			kept = append(kept, record)
+		}
+	}
+	return kept
+}
+
+// checkRecord runs validators on the fields of a record and reports whether
+// the record is kept.
+func (client *AirstackClient) checkRecord(helper string, validators []Validator, fields map[string]string) bool {
+	keep := true
+	for _, v := range validators {
+		value, ok := fields[v.Field]
+		if !ok {
+			continue
+		}
+		err := v.Check(value)
+		if err == nil {
+			continue
+		}
+		issue := ValidationIssue{
+			Helper:    helper,
+			Validator: v.Name,
+			Field:     v.Field,
+			Value:     value,
+			Err:       err,
+			Rejected:  v.Action == ValidationReject,
+		}
+		if issue.Rejected {
+			keep = false
+		}
+		if client.OnValidationIssue != nil {
+			client.OnValidationIssue(issue)
+		} else {
+			client.logger().Warn("airstack: invalid record", "helper", helper, "validator", v.Name,
+				"field", v.Field, "value", value, "error", err, "rejected", issue.Rejected)
+		}
+	}
+	return keep
+}
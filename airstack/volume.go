@@ -0,0 +1,133 @@
+package airstack
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Period is the granularity of transfer volume summaries.
+type Period string
+
+// Supported periods. Weeks start on Monday; every period is computed in UTC.
+const (
+	PeriodDay   Period = "day"
+	PeriodWeek  Period = "week"
+	PeriodMonth Period = "month"
+)
+
+// start returns the beginning of the period containing t.
+func (p Period) start(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch p {
+	case PeriodWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case PeriodMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+// TransferVolumeQuery selects the transfers aggregated by GetTransferVolume.
+// When Wallet is set, transfers are split into inbound and outbound relative
+// to it and TokenAddress optionally restricts them to one contract. Without a
+// wallet every transfer of TokenAddress only counts towards the totals. An
+// empty Period aggregates per day.
+type TransferVolumeQuery struct {
+	Blockchain   string
+	Wallet       string
+	TokenAddress string
+	Since        time.Time
+	Until        time.Time
+	Period       Period
+}
+
+// TransferSummary aggregates the transfers of a single period. Volumes are
+// sums of raw base-unit amounts.
+type TransferSummary struct {
+	PeriodStart    time.Time
+	Inbound        int
+	InboundVolume  *big.Int
+	Outbound       int
+	OutboundVolume *big.Int
+	Total          int
+	TotalVolume    *big.Int
+}
+
+// GetTransferVolume pages through the transfers selected by q and aggregates
+// them client-side into one summary per period, sorted chronologically.
+// Periods without transfers are omitted. Either Wallet or TokenAddress must
+// be set, so a query never pages through every transfer of the chain.
+func (client *AirstackClient) GetTransferVolume(ctx context.Context, q TransferVolumeQuery) ([]TransferSummary, error) {
+	if q.Wallet == "" && q.TokenAddress == "" {
+		return nil, errors.New("airstack: transfer volume query needs a wallet or a token address")
+	}
+	base := TransferFilter{Blockchain: q.Blockchain, TokenAddress: q.TokenAddress, Since: q.Since, Until: q.Until}
+	summaries := make(map[time.Time]*TransferSummary)
+	add := func(transfers []TokenTransfer, inbound bool) {
+		for _, t := range transfers {
+			amount, ok := new(big.Int).SetString(t.Amount, 10)
+			if !ok {
+				amount = new(big.Int)
+			}
+			start := q.Period.start(t.BlockTimestamp)
+			s, ok := summaries[start]
+			if !ok {
+				s = &TransferSummary{
+					PeriodStart:    start,
+					InboundVolume:  new(big.Int),
+					OutboundVolume: new(big.Int),
+					TotalVolume:    new(big.Int),
+				}
+				summaries[start] = s
+			}
+			s.Total++
+			s.TotalVolume.Add(s.TotalVolume, amount)
+			if q.Wallet == "" {
+				continue
+			}
+			if inbound {
+				s.Inbound++
+				s.InboundVolume.Add(s.InboundVolume, amount)
+			} else {
+				s.Outbound++
+				s.OutboundVolume.Add(s.OutboundVolume, amount)
+			}
+		}
+	}
+
+	if q.Wallet == "" {
+		transfers, err := client.getTokenTransfers(ctx, "GetTransferVolume", base)
+		if err != nil {
+			return nil, err
+		}
+		add(transfers, false)
+	} else {
+		in := base
+		in.To = strings.ToLower(q.Wallet)
+		inbound, err := client.getTokenTransfers(ctx, "GetTransferVolume", in)
+		if err != nil {
+			return nil, err
+		}
+		out := base
+		out.From = strings.ToLower(q.Wallet)
+		outbound, err := client.getTokenTransfers(ctx, "GetTransferVolume", out)
+		if err != nil {
+			return nil, err
+		}
+		add(inbound, true)
+		add(outbound, false)
+	}
+
+	result := make([]TransferSummary, 0, len(summaries))
+	for _, s := range summaries {
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].PeriodStart.Before(result[j].PeriodStart) })
+	return result, nil
+}
@@ -0,0 +1,20 @@
+package airstack_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vocdoni/go-airstack/airstack"
+	"github.com/vocdoni/go-airstack/airstacktest"
+)
+
+func TestGetTransferVolumeNeedsWalletOrToken(t *testing.T) {
+	s := airstacktest.NewServer()
+	defer s.Close()
+	if _, err := s.Client().GetTransferVolume(context.Background(), airstack.TransferVolumeQuery{Blockchain: "ethereum"}); err == nil {
+		t.Fatal("want an error for a query without wallet nor token address")
+	}
+	if n := len(s.Requests()); n != 0 {
+		t.Fatalf("got %d requests, want none", n)
+	}
+}
@@ -0,0 +1,64 @@
+package airstack
+
+import "context"
+
+// Domain represents an ENS domain.
+type Domain struct {
+	Name string `json:"name"`
+}
+
+// Wallet represents the identity, addresses, domains and social profiles of a
+// wallet.
+type Wallet struct {
+	Identity      string   `json:"identity"`
+	Addresses     []string `json:"addresses"`
+	PrimaryDomain *Domain  `json:"primaryDomain"`
+	Domains       []Domain `json:"domains"`
+	Socials       []Social `json:"socials"`
+	// Errors lists the sections that failed in soft-fail mode.
+	Errors []SectionError `json:"-"`
+}
+
+const walletQuery = `
+query GetWallet($identity: Identity!) {
+	Wallet(input: {identity: $identity, blockchain: ethereum}) {
+		identity
+		addresses
+		primaryDomain {
+			name
+		}
+		domains {
+			name
+		}
+		socials {
+			dappName
+			profileName
+			profileImage
+			userId
+			followerCount
+			followingCount
+			userAssociatedAddresses
+		}
+	}
+}
+`
+
+// GetWallet returns the wallet behind identity, which may be an address, an
+// ENS name or a dapp identity such as "fc_fid:1". With a WithSoftFail context,
+// failing sections are reported in the wallet Errors.
+func (client *AirstackClient) GetWallet(ctx context.Context, identity string) (*Wallet, error) {
+	var respData struct {
+		Wallet *Wallet `json:"Wallet"`
+	}
+	sections, err := client.queryComposite(ctx, walletQuery, map[string]interface{}{"identity": identity}, &respData)
+	if err != nil {
+		return nil, err
+	}
+	if len(sections) > 0 {
+		if respData.Wallet == nil {
+			respData.Wallet = &Wallet{Identity: identity}
+		}
+		respData.Wallet.Errors = sections
+	}
+	return respData.Wallet, nil
+}
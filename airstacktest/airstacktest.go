@@ -0,0 +1,115 @@
+// Package airstacktest provides a fake Airstack GraphQL server for testing
+// code built on the airstack package.
+package airstacktest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/vocdoni/go-airstack/airstack"
+)
+
+// Request is a GraphQL request received by the fake server.
+type Request struct {
+	Operation string
+	Query     string
+	Variables map[string]interface{}
+}
+
+// Response is a canned answer of the fake server.
+type Response struct {
+	StatusCode int
+	Body       []byte
+}
+
+// HandlerFunc computes the response of a request.
+type HandlerFunc func(Request) Response
+
+// Server is a fake Airstack API answering every request with the response
+// registered for its operation name.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	handlers map[string]HandlerFunc
+	requests []Request
+}
+
+// NewServer starts a fake server. Callers must Close it when done.
+func NewServer() *Server {
+	s := &Server{handlers: make(map[string]HandlerFunc)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Handle registers the response returned for requests of operation.
+func (s *Server) Handle(operation string, statusCode int, body []byte) {
+	s.HandleFunc(operation, func(Request) Response {
+		return Response{StatusCode: statusCode, Body: body}
+	})
+}
+
+// HandleFunc registers the function answering requests of operation.
+func (s *Server) HandleFunc(operation string, fn HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[operation] = fn
+}
+
+// Requests returns the requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// Client returns an AirstackClient sending its requests to the server.
+func (s *Server) Client() *airstack.AirstackClient {
+	client := airstack.NewAirstackClient("test")
+	client.URL = s.URL
+	return client
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables"`
+	}
+	data, err := io.ReadAll(r.Body)
+	if err == nil {
+		err = json.Unmarshal(data, &body)
+	}
+	if err != nil {
+		http.Error(w, `{"errors":[{"message":"invalid request body"}]}`, http.StatusBadRequest)
+		return
+	}
+	req := Request{Operation: airstack.OperationName(body.Query), Query: body.Query, Variables: body.Variables}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	handler, ok := s.handlers[req.Operation]
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if !ok {
+		fmt.Fprintf(w, `{"errors":[{"message":"airstacktest: no response for operation %s"}]}`, req.Operation)
+		return
+	}
+	resp := handler(req)
+	w.WriteHeader(resp.StatusCode)
+	_, _ = w.Write(resp.Body)
+}
+
+// ServeReproduction registers the recorded response of r, so replaying it
+// against the server exercises the client decoding path offline.
+func (s *Server) ServeReproduction(r *airstack.Reproduction) {
+	status := r.StatusCode
+	if status == 0 {
+		status = http.StatusOK
+	}
+	s.Handle(r.Operation, status, r.Response)
+}
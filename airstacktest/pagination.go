@@ -0,0 +1,145 @@
+package airstacktest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// defaultFixturePageSize is the page size used when a request has no $limit.
+const defaultFixturePageSize = 200
+
+// PaginationFixture serves Items as the pages of a paginated root and can
+// simulate the pagination failures seen against the real API. Cursors are
+// opaque strings of the form "offset:<n>" holding the offset of the first
+// item of the page, so walks changing their $limit between pages, as adaptive
+// paging does, still see every item exactly once.
+//
+// The failure scenarios are keyed on the number of pages served so far, which
+// counts every successful page answered by the fixture, so a fixture is meant
+// to serve a single walk.
+type PaginationFixture struct {
+	// Root and Field name the paginated root and its item list, e.g.
+	// "TokenBalances" and "TokenBalance".
+	Root  string
+	Field string
+	Items []json.RawMessage
+
+	// EmptyLastPage serves an extra empty page, with its own cursor, after
+	// the last page holding items.
+	EmptyLastPage bool
+	// Duplicates repeats the last Duplicates items of each page at the
+	// start of the following one.
+	Duplicates int
+	// ExpiredCursorPage, when positive, makes the requests sent once that
+	// many pages were served fail with a "cursor expired" GraphQL error. The
+	// first ExpiredCursorTimes requests fail, or all of them when
+	// ExpiredCursorTimes is zero.
+	ExpiredCursorPage  int
+	ExpiredCursorTimes int
+	// RateLimitPage, when positive, answers the first RateLimitTimes
+	// requests (one when zero) sent once that many pages were served with
+	// HTTP 429, as happens in the middle of long exports.
+	RateLimitPage  int
+	RateLimitTimes int
+
+	mu      sync.Mutex
+	served  int
+	expired int
+	limited int
+}
+
+// Handler returns the HandlerFunc serving the fixture.
+func (f *PaginationFixture) Handler() HandlerFunc {
+	return f.serve
+}
+
+// pageInfo is the pageInfo object of a served page.
+type pageInfo struct {
+	NextCursor string `json:"nextCursor"`
+	PrevCursor string `json:"prevCursor"`
+}
+
+// cursor returns the cursor of the page starting at offset.
+func cursor(offset int) string {
+	return "offset:" + strconv.Itoa(offset)
+}
+
+func (f *PaginationFixture) serve(req Request) Response {
+	offset := 0
+	if c, _ := req.Variables["cursor"].(string); c != "" {
+		n, err := strconv.Atoi(strings.TrimPrefix(c, "offset:"))
+		if err != nil || n < 0 || n > len(f.Items) || !strings.HasPrefix(c, "offset:") {
+			return graphQLError(fmt.Sprintf("invalid cursor %q", c))
+		}
+		offset = n
+	}
+	size := defaultFixturePageSize
+	if limit, ok := req.Variables["limit"].(float64); ok && limit > 0 {
+		size = int(limit)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.RateLimitPage > 0 && f.served == f.RateLimitPage && f.limited < max(f.RateLimitTimes, 1) {
+		f.limited++
+		return Response{StatusCode: http.StatusTooManyRequests, Body: []byte(`{"errors":[{"message":"rate limit exceeded"}]}`)}
+	}
+	if f.ExpiredCursorPage > 0 && f.served == f.ExpiredCursorPage && (f.ExpiredCursorTimes == 0 || f.expired < f.ExpiredCursorTimes) {
+		f.expired++
+		return graphQLError("cursor expired")
+	}
+
+	end := min(offset+size, len(f.Items))
+	items := append([]json.RawMessage{}, f.Items[offset:end]...)
+	if offset > 0 && f.Duplicates > 0 && offset < len(f.Items) {
+		items = append(append([]json.RawMessage{}, f.Items[max(offset-f.Duplicates, 0):offset]...), items...)
+	}
+
+	info := pageInfo{}
+	if offset > 0 {
+		info.PrevCursor = cursor(max(offset-size, 0))
+	}
+	if end < len(f.Items) || (f.EmptyLastPage && offset < len(f.Items)) {
+		info.NextCursor = cursor(end)
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"data": map[string]interface{}{
+			f.Root: map[string]interface{}{
+				f.Field:    items,
+				"pageInfo": info,
+			},
+		},
+	})
+	if err != nil {
+		return graphQLError(err.Error())
+	}
+	f.served++
+	return Response{StatusCode: http.StatusOK, Body: body}
+}
+
+// graphQLError returns a response carrying a single GraphQL error.
+func graphQLError(message string) Response {
+	body, _ := json.Marshal(map[string]interface{}{
+		"data":   nil,
+		"errors": []map[string]string{{"message": message}},
+	})
+	return Response{StatusCode: http.StatusOK, Body: body}
+}
+
+// TokenBalanceItems generates n TokenBalance items held by distinct owners,
+// with amounts 1 to n, as returned by the holder queries of the airstack
+// package.
+func TokenBalanceItems(n int) []json.RawMessage {
+	items := make([]json.RawMessage, n)
+	for i := range items {
+		addr := fmt.Sprintf("0x%040x", i+1)
+		items[i] = json.RawMessage(fmt.Sprintf(
+			`{"owner":{"identity":%q,"addresses":[%q]},"amount":"%d","tokenId":""}`, addr, addr, i+1))
+	}
+	return items
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"math/big"
+
+	"github.com/vocdoni/go-airstack/airstack"
+)
+
+// amount runs the amount command: it renders a raw base-unit amount with the
+// decimals and symbol of a token, or of the native token of -chain, and with
+// -parse converts human input back to base units.
+func amount(args []string) error {
+	fs := flag.NewFlagSet("amount", flag.ExitOnError)
+	decimals := fs.Int("decimals", 18, "token decimals")
+	symbol := fs.String("symbol", "", "token symbol")
+	precision := fs.Int("precision", 0, "maximum fractional digits shown, 0 for all and -1 for none")
+	chain := fs.String("chain", "", "use the native token of this blockchain")
+	parse := fs.Bool("parse", false, "parse human input into base units")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("usage: amount [-decimals N] [-symbol S] [-precision P] [-chain C] [-parse] value")
+	}
+	if *chain != "" {
+		currency, err := airstack.LookupNativeCurrency(*chain)
+		if err != nil {
+			return err
+		}
+		*decimals, *symbol = currency.Decimals, currency.Symbol
+	}
+
+	if *parse {
+		value, err := airstack.ParseAmount(fs.Arg(0), *decimals)
+		if err != nil {
+			return err
+		}
+		fmt.Println(value)
+		return nil
+	}
+	value, ok := new(big.Int).SetString(fs.Arg(0), 10)
+	if !ok {
+		return fmt.Errorf("invalid base-unit amount %q", fs.Arg(0))
+	}
+	fmt.Println(airstack.FormatAmount(value, airstack.AmountFormat{Decimals: *decimals, Precision: *precision, Symbol: *symbol}))
+	return nil
+}
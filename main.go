@@ -3,11 +3,35 @@ package main
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/vocdoni/go-airstack/airstack"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "replay":
+			if err := replay(os.Args[2:]); err != nil {
+				fmt.Println("Error replaying reproduction:", err)
+				os.Exit(1)
+			}
+			return
+		case "amount":
+			if err := amount(os.Args[2:]); err != nil {
+				fmt.Println("Error converting amount:", err)
+				os.Exit(1)
+			}
+			return
+		case "schema-diff":
+			if err := schemaDiff(os.Args[2:]); err != nil {
+				fmt.Println("Error diffing schema:", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	client := airstack.NewAirstackClient("your_api_key_here")
 
 	variables := map[string]interface{}{
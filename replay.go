@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/vocdoni/go-airstack/airstack"
+	"github.com/vocdoni/go-airstack/airstacktest"
+)
+
+// replay runs the replay command: it sends the call recorded in a reproduction
+// file to a fake server serving the recorded response, or to the live API
+// with -live, and prints the outcome.
+func replay(args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	live := fs.Bool("live", false, "replay against the live Airstack API instead of a fake server")
+	apiKey := fs.String("key", os.Getenv("AIRSTACK_API_KEY"), "Airstack API key used with -live")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("usage: replay [-live] [-key KEY] <reproduction.json>")
+	}
+
+	repro, err := airstack.LoadReproduction(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	var client *airstack.AirstackClient
+	if *live {
+		client = airstack.NewAirstackClient(*apiKey)
+	} else {
+		server := airstacktest.NewServer()
+		defer server.Close()
+		server.ServeReproduction(repro)
+		client = server.Client()
+	}
+
+	resp, err := repro.Replay(context.Background(), client)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Operation: %s, Status Code: %d\n", repro.Operation, resp.StatusCode)
+	if resp.Error != "" {
+		fmt.Println("Error:", resp.Error)
+	}
+	fmt.Println(string(resp.Raw))
+	return nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+
+	"github.com/vocdoni/go-airstack/airstack"
+)
+
+// schemaDiff runs the schema-diff command: it introspects the live schema,
+// diffs it against the vendored snapshot and prints the changes as JSON. With
+// -write it also writes the live snapshot to the given path, to refresh the
+// vendored one.
+func schemaDiff(args []string) error {
+	fs := flag.NewFlagSet("schema-diff", flag.ExitOnError)
+	apiKey := fs.String("key", os.Getenv("AIRSTACK_API_KEY"), "Airstack API key")
+	write := fs.String("write", "", "write the live snapshot to this path")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	client := airstack.NewAirstackClient(*apiKey)
+	ctx := context.Background()
+	vendored, err := airstack.VendoredSchemaSnapshot()
+	if err != nil {
+		return err
+	}
+	live, err := client.SnapshotSchema(ctx)
+	if err != nil {
+		return err
+	}
+
+	if *write != "" {
+		data, err := json.MarshalIndent(live, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(*write, append(data, '\n'), 0o644); err != nil {
+			return err
+		}
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Changes []airstack.SchemaChange `json:"changes"`
+	}{airstack.DiffSchemaSnapshots(vendored, live)})
+}